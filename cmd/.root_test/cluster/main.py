@@ -0,0 +1,2 @@
+from .users.api import *
+from .orders.api import *
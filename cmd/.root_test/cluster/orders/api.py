@@ -0,0 +1,3 @@
+from .model import *
+from .db import *
+from ..users.api import *
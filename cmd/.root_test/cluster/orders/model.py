@@ -0,0 +1,2 @@
+def order():
+    pass
@@ -0,0 +1,2 @@
+def format_user():
+    pass
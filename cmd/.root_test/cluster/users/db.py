@@ -0,0 +1,2 @@
+from .model import *
+from ..orders.user_format import *
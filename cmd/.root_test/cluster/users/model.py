@@ -0,0 +1 @@
+from ..orders.user_format import *
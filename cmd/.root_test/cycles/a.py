@@ -0,0 +1 @@
+from .b import *
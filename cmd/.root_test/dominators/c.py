@@ -0,0 +1 @@
+from .d import *
@@ -0,0 +1,6 @@
+from .lib import parse, render
+
+try:
+    from .optional import extra
+except ImportError:
+    extra = None
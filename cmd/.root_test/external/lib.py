@@ -0,0 +1,5 @@
+from yaml import safe_load
+
+
+def load(path):
+    return safe_load(path)
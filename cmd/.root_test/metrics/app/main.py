@@ -0,0 +1,2 @@
+from ..domain.user import *
+from ..db.sql import *
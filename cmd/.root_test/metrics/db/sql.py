@@ -0,0 +1 @@
+from ..domain.repo import *
@@ -0,0 +1,5 @@
+from .b import b
+
+
+def a():
+    return b()
@@ -0,0 +1,2 @@
+def dead():
+    return 3
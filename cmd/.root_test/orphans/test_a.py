@@ -0,0 +1,6 @@
+from .a import a
+from .helper import helper
+
+
+def test_a():
+    assert a() == helper()
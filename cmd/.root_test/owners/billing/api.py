@@ -0,0 +1,6 @@
+from ..users.api import get_user
+from ..shared.money import format_amount
+
+
+def charge(user_id, amount):
+    return get_user(user_id), format_amount(amount)
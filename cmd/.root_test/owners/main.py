@@ -0,0 +1,2 @@
+from .billing.api import charge
+from .users.api import get_user
@@ -0,0 +1,2 @@
+def format_amount(amount):
+    return f"{amount:.2f}"
@@ -0,0 +1,5 @@
+from ..shared.money import format_amount
+
+
+def get_user(user_id):
+    return user_id
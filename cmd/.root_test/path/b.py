@@ -0,0 +1,6 @@
+from .c import baz
+from .a import *
+
+
+def foo():
+    pass
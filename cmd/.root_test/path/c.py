@@ -0,0 +1,6 @@
+def bar():
+    pass
+
+
+def baz():
+    pass
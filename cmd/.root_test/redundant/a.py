@@ -0,0 +1,2 @@
+from .b import *
+from .c import *
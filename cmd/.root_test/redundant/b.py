@@ -0,0 +1 @@
+from .c import *
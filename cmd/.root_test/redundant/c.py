@@ -0,0 +1 @@
+foo = 1
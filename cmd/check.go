@@ -3,10 +3,13 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/cpp"
 	"github.com/gabotechs/dep-tree/internal/graph"
 	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/metrics"
 	"github.com/spf13/cobra"
 
 	"github.com/gabotechs/dep-tree/internal/check"
@@ -36,12 +39,72 @@ func CheckCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 			}
 			parser := language.NewParser(lang)
 			applyConfigToParser(parser, cfg)
+			entrypoints := make([]string, len(cfg.Check.Entrypoints))
+			for i, file := range cfg.Check.Entrypoints {
+				entrypoints[i] = filepath.Join(cfg.Check.Path, file)
+			}
+			nodeParser, _, err := groupFiles(parser, entrypoints, cfg)
+			if err != nil {
+				return err
+			}
+
+			var rules []check.Rule[*language.FileInfo]
+			if cppLang, ok := lang.(*cpp.Language); ok && cfg.Cpp.ForbidSourceIncludes {
+				if cfg.GroupBy != "" {
+					return errors.New("the forbidSourceIncludes C++ check is not supported together with --group-by, as files are checked individually")
+				}
+				rules = append(rules, check.Rule[*language.FileInfo]{
+					Title: "source files included as if they were headers",
+					Run:   cppLang.CheckSourceIncludes,
+				})
+			}
+			if cppLang, ok := lang.(*cpp.Language); ok && cfg.Cpp.SelfContained.Mode != "" {
+				if cfg.GroupBy != "" {
+					return errors.New("the selfContained C++ check is not supported together with --group-by, as headers are checked individually")
+				}
+				rules = append(rules, check.Rule[*language.FileInfo]{
+					Title: "headers that are not self-contained",
+					Run:   cppLang.CheckSelfContained,
+				})
+			}
+			if thresholds := cfg.Check.Metrics; thresholds.MaxDistance > 0 {
+				if thresholds.GroupBy == "" {
+					thresholds.GroupBy = "dir"
+				}
+				groupBy, err := language.ParseGroupBy(thresholds.GroupBy)
+				if err != nil {
+					return err
+				}
+				if cfg.GroupBy != "" {
+					// Files are already collapsed into groups, so each node is its own group.
+					groupBy = func(file *language.FileInfo) string { return file.RelPath }
+				}
+				rules = append(rules, check.Rule[*language.FileInfo]{
+					Title: fmt.Sprintf("groups further than %g from the main sequence", thresholds.MaxDistance),
+					Run:   metrics.MaxDistanceRule(thresholds.MaxDistance, groupBy),
+				})
+			}
+
+			if len(cfg.Check.Owners) > 0 {
+				if cfg.GroupBy != "" {
+					return errors.New("owners rules are not supported together with --group-by, as files are owned individually")
+				}
+				codeOwners, err := loadCodeOwners("", cfg)
+				if err != nil {
+					return err
+				}
+				rules = append(rules, check.Rule[*language.FileInfo]{
+					Title: "dependencies across code owners that are not allowed",
+					Run:   codeOwners.Rule(cfg.Check.Owners, relPathDisplay),
+				})
+			}
 
 			return check.Check[*language.FileInfo](
-				parser,
+				nodeParser,
 				relPathDisplay,
 				&cfg.Check,
 				graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay),
+				rules...,
 			)
 		},
 	}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gabotechs/dep-tree/internal/cluster"
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/spf13/cobra"
+)
+
+func ClusterCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var groupBySpec string
+	var resolution float64
+
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Finds groups of files that depend on each other and compares them with the current layout",
+		Long: `Finds clusters of files that depend much more on each other than on the rest of the files, and
+compares them with how files are currently grouped. The modularity measures how well files are
+split, from -0.5 to 1, and files whose cluster disagrees with their group are listed as candidates
+for being moved.`,
+		GroupID: explainGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupBy, err := language.ParseGroupBy(groupBySpec)
+			if err != nil {
+				return err
+			}
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the cluster command, use --package-by")
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, parser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			report := cluster.Analyze(g, groupBy, resolution)
+			cmd.Printf(
+				"found %s with a modularity of %.2f, the current layout has a modularity of %.2f\n",
+				pluralize(len(report.Clusters), "cluster"), report.Modularity, report.LayoutModularity,
+			)
+			cmd.Printf("%.0f%% of the files are in the same cluster as most of the files in their group\n", report.Agreement*100)
+			for i, c := range report.Clusters {
+				cmd.Printf("\ncluster %d (%s):\n", i+1, pluralize(len(c), "file"))
+				var groupNames []string
+				groupCounts := map[string]int{}
+				for _, node := range c {
+					group := groupBy(node.Data)
+					if groupCounts[group] == 0 {
+						groupNames = append(groupNames, group)
+					}
+					groupCounts[group]++
+				}
+				for _, group := range groupNames {
+					cmd.Printf("  %s (%s)\n", group, pluralize(groupCounts[group], "file"))
+				}
+			}
+			if len(report.Misplaced) > 0 {
+				cmd.Println("\nfiles that might belong to a different group:")
+				for _, m := range report.Misplaced {
+					cmd.Printf("- %s: %s -> %s\n", relPathDisplay(m.File), m.Group, m.Suggested)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&groupBySpec, "package-by", "dir", `how files are currently grouped: "package", "dir" or "dir:<depth>"`)
+	cmd.Flags().Float64Var(&resolution, "resolution", 1, "higher values find more and smaller clusters, lower values less and bigger clusters")
+
+	return cmd
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/gabotechs/dep-tree/internal/cochange"
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/revision"
+	"github.com/spf13/cobra"
+)
+
+// defaultCoChangeOptions are the thresholds for reporting files that change together.
+var defaultCoChangeOptions = cochange.Options{MinCoChanges: 3, MinCoupling: 0.5, MaxCommitSize: 30}
+
+func CoChangeCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var commits int
+	var opts cochange.Options
+	var top int
+	var jsonFormat bool
+
+	cmd := &cobra.Command{
+		Use:   "co-change",
+		Short: "Compares how often files change together in git with how they depend on each other",
+		Long: `Walks the last commits in the git history and counts how often each pair of files changed
+together. The coupling of two files is the amount of commits where they changed together divided
+by the average amount of commits where each of them changed, from 0 to 1.
+
+Two kinds of pairs are highlighted:
+
+  Hidden coupling: files that often change together but do not depend on each other, which
+                   usually means that they are coupled in a way that is not visible in the code.
+  Never together:  dependencies whose files changed often but never in the same commit, which
+                   might mean that the dependency can be removed.`,
+		GroupID: explainGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if commits <= 0 {
+				return fmt.Errorf("--commits must be a positive integer, got %d", commits)
+			}
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the co-change command, as git tracks changes in files")
+			}
+			changeSets, err := loadChangeSets(commits)
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, parser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			report := cochange.Analyze(g, changeSets, opts)
+			for _, pairs := range []*[]cochange.Pair{&report.Pairs, &report.Hidden, &report.NeverTogether} {
+				for i := range *pairs {
+					(*pairs)[i].A = relPathDisplay(g.Get((*pairs)[i].A))
+					(*pairs)[i].B = relPathDisplay(g.Get((*pairs)[i].B))
+				}
+				if top > 0 && len(*pairs) > top {
+					*pairs = (*pairs)[:top]
+				}
+			}
+
+			if jsonFormat {
+				rendered, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(rendered))
+				return nil
+			}
+
+			cmd.Printf("analyzed %s\n", pluralize(report.Commits, "commit"))
+			cmd.Println("\nfiles that change together without depending on each other:")
+			if len(report.Hidden) == 0 {
+				cmd.Println("none")
+			} else {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "FILE A\tFILE B\tCO-CHANGES\tCOUPLING")
+				for _, p := range report.Hidden {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%.2f\n", p.A, p.B, p.CoChanges, p.Coupling)
+				}
+				if err := w.Flush(); err != nil {
+					return err
+				}
+			}
+			cmd.Println("\ndependencies whose files never change together:")
+			if len(report.NeverTogether) == 0 {
+				cmd.Println("none")
+			} else {
+				w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+				_, _ = fmt.Fprintln(w, "FROM\tTO\tFROM CHANGES\tTO CHANGES")
+				for _, p := range report.NeverTogether {
+					_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", p.A, p.B, p.ChangesA, p.ChangesB)
+				}
+				return w.Flush()
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&commits, "commits", 500, "amount of commits from HEAD that are analyzed")
+	cmd.Flags().IntVar(&opts.MinCoChanges, "min-co-changes", defaultCoChangeOptions.MinCoChanges, "minimum amount of commits in which two files must have changed for being reported")
+	cmd.Flags().Float64Var(&opts.MinCoupling, "min-coupling", defaultCoChangeOptions.MinCoupling, "minimum coupling, from 0 to 1, of the reported files that change together")
+	cmd.Flags().IntVar(&opts.MaxCommitSize, "max-commit-size", defaultCoChangeOptions.MaxCommitSize, "commits that change more files than this are ignored. 0 means no limit")
+	cmd.Flags().IntVar(&top, "top", 20, "maximum amount of pairs of files displayed. 0 displays all of them")
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "render the results in a machine readable json format")
+
+	return cmd
+}
+
+// loadChangeSets reads the files changed by the last commits of the git repository where
+// dep-tree is executed.
+func loadChangeSets(commits int) ([][]string, error) {
+	cwd, _ := os.Getwd()
+	repo, err := revision.Open(cwd)
+	if err != nil {
+		return nil, err
+	}
+	return repo.ChangeSets(commits)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/spf13/cobra"
+)
+
+// maxCountedCycles bounds how many cycles are counted for each suggested import.
+const maxCountedCycles = 1000
+
+func CyclesCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var suggest bool
+	var maxCycles int
+
+	cmd := &cobra.Command{
+		Use:     "cycles",
+		Short:   "Lists the groups of files that depend on each other, and suggests which imports to remove",
+		GroupID: checkGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, nodeParser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			display := func(ids []string) []string {
+				result := make([]string, len(ids))
+				for i, id := range ids {
+					result[i] = relPathDisplay(g.Get(id))
+				}
+				return result
+			}
+
+			components := g.CyclicComponents(maxCycles)
+			if len(components) == 0 {
+				cmd.Println("no circular dependencies found")
+				return nil
+			}
+			for _, component := range components {
+				if len(component.Nodes) > 1 {
+					cmd.Printf("- %d files depend on each other: %s\n", len(component.Nodes), strings.Join(display(component.Nodes), ", "))
+				} else {
+					cmd.Printf("- %s\n", display(component.Nodes)[0])
+				}
+				for _, cycle := range component.Cycles {
+					cmd.Printf("  %s\n", strings.Join(display(cycle), " -> "))
+				}
+			}
+			if !suggest {
+				return nil
+			}
+
+			type suggestion struct {
+				edge   graph.FeedbackEdge
+				cycles int
+				exact  bool
+			}
+			var suggestions []suggestion
+			edges := g.FeedbackArcSet()
+			for i, count := range g.CountCyclesThrough(edges, maxCountedCycles) {
+				suggestions = append(suggestions, suggestion{edges[i], count.Count, count.Exact})
+			}
+			slices.SortStableFunc(suggestions, func(a, b suggestion) int { return b.cycles - a.cycles })
+
+			cmd.Println()
+			if len(suggestions) == 1 {
+				cmd.Println("removing this import breaks all the circular dependencies:")
+			} else {
+				cmd.Printf("removing these %d imports breaks all the circular dependencies:\n", len(suggestions))
+			}
+			for _, s := range suggestions {
+				count := fmt.Sprintf("%d", s.cycles)
+				if !s.exact {
+					count += "+"
+				}
+				noun := "cycles"
+				if s.cycles == 1 && s.exact {
+					noun = "cycle"
+				}
+				edge := display([]string{s.edge.From, s.edge.To})
+				cmd.Printf("- %s -> %s (breaks %s %s)\n", edge[0], edge[1], count, noun)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&suggest, "suggest", false, "suggest the smallest set of imports that need to be removed for breaking all the cycles")
+	cmd.Flags().IntVar(&maxCycles, "max-cycles", 5, "maximum amount of cycles displayed for each group of files that depend on each other")
+
+	return cmd
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/gabotechs/dep-tree/internal/check"
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/cpp"
+	"github.com/gabotechs/dep-tree/internal/diff"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/revision"
+	"github.com/spf13/cobra"
+)
+
+func DiffCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Shows how the dependency graph changed between two git revisions",
+		Long: `Shows how the dependency graph changed between two git revisions: the files and
+dependencies that were added or removed, the new and resolved circular dependencies, and
+the check violations that were introduced or fixed. The first two arguments are the
+revisions and the rest are the entrypoints.
+
+Files are read from the git objects into a temporary directory, so the working tree is never
+modified. Only the files in the folder of the configuration file, in the C++ include paths and
+next to the entrypoints are extracted, together with the ones directly in their parent folders.
+The configuration file of the working tree is used for both revisions.`,
+		GroupID: checkGroupId,
+		Args:    cobra.MinimumNArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" && format != "markdown" {
+				return fmt.Errorf(`invalid format "%s", allowed values are "text", "json" or "markdown"`, format)
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			cwd, _ := os.Getwd()
+			repo, err := revision.Open(cwd)
+			if err != nil {
+				return err
+			}
+
+			var snapshots [2]diff.Snapshot[*language.FileInfo]
+			for i, rev := range args[:2] {
+				dir, err := os.MkdirTemp("", "dep-tree-diff-")
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+				snapshots[i], err = loadRevision(repo, rev, dir, args[2:], cfg)
+				if err != nil {
+					return err
+				}
+			}
+
+			d := diff.Compare(snapshots[0], snapshots[1])
+			switch format {
+			case "json":
+				rendered, err := json.MarshalIndent(d, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(rendered))
+			case "markdown":
+				cmd.Print(renderDiffMarkdown(args[0], args[1], d))
+			default:
+				cmd.Print(renderDiffText(d))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", `output format: "text", "json" or "markdown", which is meant for pull request comments`)
+
+	return cmd
+}
+
+// loadRevision extracts the repository at rev into dir and loads the graph from the entrypoints
+// there, as if dep-tree was executed from the same place in the repository as now.
+func loadRevision(
+	repo *revision.Repository,
+	rev string,
+	dir string,
+	entrypoints []string,
+	cfg *config.Config,
+) (diff.Snapshot[*language.FileInfo], error) {
+	var snapshot diff.Snapshot[*language.FileInfo]
+	cwd, _ := os.Getwd()
+	absEntrypoints := make([]string, len(entrypoints))
+	for i, entrypoint := range entrypoints {
+		if !filepath.IsAbs(entrypoint) {
+			entrypoint = filepath.Join(cwd, entrypoint)
+		}
+		absEntrypoints[i] = entrypoint
+	}
+	// C++ mappings are relative to the current directory.
+	mappings := make([]cpp.PathMapping, len(cfg.Cpp.PathMappings))
+	for i, mapping := range cfg.Cpp.PathMappings {
+		mappings[i] = cpp.PathMapping{From: mapping.From, To: mapping.To}
+		if !filepath.IsAbs(mapping.From) {
+			mappings[i].From = filepath.Join(cwd, mapping.From)
+		}
+		if !filepath.IsAbs(mapping.To) {
+			mappings[i].To = filepath.Join(cwd, mapping.To)
+		}
+	}
+
+	// Only the files that might be parsed are extracted: the ones in the folder of the
+	// configuration, in the C++ include paths and mappings, and next to the entrypoints.
+	dirs := []string{cfg.Path}
+	dirs = append(dirs, cfg.Cpp.RecursiveIncludePaths...)
+	dirs = append(dirs, cfg.Cpp.NonRecursiveIncludePaths...)
+	for _, mapping := range mappings {
+		dirs = append(dirs, mapping.From, mapping.To)
+	}
+	for _, entrypoint := range absEntrypoints {
+		base, _ := doublestar.SplitPattern(filepath.ToSlash(entrypoint))
+		dirs = append(dirs, filepath.FromSlash(base))
+	}
+	if err := repo.Extract(rev, dir, dirs...); err != nil {
+		return snapshot, err
+	}
+	// Points the absolute paths in the working tree to the same place in dir.
+	rebase := func(path string) string {
+		rel, err := filepath.Rel(repo.Root, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			return path
+		}
+		return filepath.Join(dir, rel)
+	}
+	rebaseAll := func(paths []string) []string {
+		result := make([]string, len(paths))
+		for i, path := range paths {
+			result[i] = rebase(path)
+		}
+		return result
+	}
+
+	revCfg := *cfg
+	revCfg.Path = rebase(cfg.Path)
+	revCfg.Check.Path = rebase(cfg.Check.Path)
+	revCfg.Exclude = rebaseAll(cfg.Exclude)
+	revCfg.Only = rebaseAll(cfg.Only)
+	revCfg.CacheDir = ""
+	revCfg.Cpp.RecursiveIncludePaths = rebaseAll(cfg.Cpp.RecursiveIncludePaths)
+	revCfg.Cpp.NonRecursiveIncludePaths = rebaseAll(cfg.Cpp.NonRecursiveIncludePaths)
+	revCfg.Cpp.PathMappings = make([]cpp.PathMapping, len(mappings))
+	for i, mapping := range mappings {
+		revCfg.Cpp.PathMappings[i] = cpp.PathMapping{From: rebase(mapping.From), To: rebase(mapping.To)}
+	}
+
+	files, err := filesFromArgs(rebaseAll(absEntrypoints))
+	if err != nil {
+		return snapshot, fmt.Errorf("in revision %s: %w", rev, err)
+	}
+	lang, err := inferLang(files, &revCfg)
+	if err != nil {
+		return snapshot, err
+	}
+	parser := language.NewParser(lang)
+	applyConfigToParser(parser, &revCfg)
+	nodeParser, files, err := groupFiles(parser, files, &revCfg)
+	if err != nil {
+		return snapshot, err
+	}
+
+	g := graph.NewGraph[*language.FileInfo]()
+	err = g.Load(files, nodeParser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.Graph = g
+	snapshot.Key = func(node *graph.Node[*language.FileInfo]) string {
+		// Nodes that are groups of files are not identified by a path.
+		if !filepath.IsAbs(node.Id) {
+			return node.Id
+		}
+		if rel, err := filepath.Rel(dir, node.Id); err == nil {
+			return filepath.ToSlash(rel)
+		}
+		return node.Id
+	}
+	if cfg.Source != "default" {
+		snapshot.Violations, err = check.Violations(g, &revCfg.Check)
+	}
+	return snapshot, err
+}
+
+type diffSection struct {
+	title   string
+	added   []string
+	removed []string
+}
+
+func diffSections(d diff.Diff) []diffSection {
+	edges := func(edges []diff.Edge) []string {
+		result := make([]string, len(edges))
+		for i, edge := range edges {
+			result[i] = edge.From + " -> " + edge.To
+		}
+		return result
+	}
+	cycles := func(cycles [][]string) []string {
+		result := make([]string, len(cycles))
+		for i, cycle := range cycles {
+			result[i] = strings.Join(cycle, " -> ")
+		}
+		return result
+	}
+	violations := func(violations []check.Violation) []string {
+		result := make([]string, len(violations))
+		for i, violation := range violations {
+			result[i] = violation.From
+			if violation.Line > 0 {
+				result[i] += fmt.Sprintf(":%d", violation.Line)
+			}
+			result[i] += " -> " + violation.To
+			if violation.Reason != "" {
+				result[i] += ": " + strings.ReplaceAll(violation.Reason, "\n", " ")
+			}
+		}
+		return result
+	}
+	return []diffSection{
+		{"files", d.AddedFiles, d.RemovedFiles},
+		{"dependencies", edges(d.AddedDependencies), edges(d.RemovedDependencies)},
+		{"circular dependencies", cycles(d.NewCycles), cycles(d.ResolvedCycles)},
+		{"check violations", violations(d.NewViolations), violations(d.ResolvedViolations)},
+	}
+}
+
+func renderDiffText(d diff.Diff) string {
+	if d.IsEmpty() {
+		return "no changes in the dependency graph\n"
+	}
+	sb := strings.Builder{}
+	for _, section := range diffSections(d) {
+		for _, part := range []struct {
+			verb   string
+			prefix string
+			lines  []string
+		}{
+			{"added", "+", section.added},
+			{"removed", "-", section.removed},
+		} {
+			if len(part.lines) == 0 {
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(fmt.Sprintf("%s %s (%d):\n", section.title, part.verb, len(part.lines)))
+			for _, line := range part.lines {
+				sb.WriteString(part.prefix + " " + line + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+func renderDiffMarkdown(from string, to string, d diff.Diff) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("### Dependency changes from `%s` to `%s`\n\n", from, to))
+	if d.IsEmpty() {
+		sb.WriteString("No changes in the dependency graph.\n")
+		return sb.String()
+	}
+	sections := diffSections(d)
+	sb.WriteString("| | Added | Removed |\n")
+	sb.WriteString("|---|---|---|\n")
+	for _, section := range sections {
+		title := strings.ToUpper(section.title[:1]) + section.title[1:]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d |\n", title, len(section.added), len(section.removed)))
+	}
+	for _, section := range sections {
+		if len(section.added) == 0 && len(section.removed) == 0 {
+			continue
+		}
+		title := strings.ToUpper(section.title[:1]) + section.title[1:]
+		sb.WriteString(fmt.Sprintf("\n<details>\n<summary>%s</summary>\n", title))
+		for _, part := range []struct {
+			title string
+			lines []string
+		}{
+			{"Added", section.added},
+			{"Removed", section.removed},
+		} {
+			if len(part.lines) == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n%s:\n", part.title))
+			for _, line := range part.lines {
+				sb.WriteString("- `" + line + "`\n")
+			}
+		}
+		sb.WriteString("\n</details>\n")
+	}
+	return sb.String()
+}
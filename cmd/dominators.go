@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/spf13/cobra"
+)
+
+func DominatorsCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var top int
+
+	cmd := &cobra.Command{
+		Use:   "dominators",
+		Short: "Lists the files that everything below them must go through, starting from an entrypoint",
+		Long: `Lists the files that everything below them must go through, starting from an entrypoint.
+A file dominates another one if every path from the entrypoint to the latter goes through
+the former, so the dominated files are only needed because of it. These files are good
+candidates for being split apart or lazy-loaded together.`,
+		GroupID: explainGroupId,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(files) != 1 {
+				return fmt.Errorf("a single entrypoint is expected, but %d files matched %s", len(files), args[0])
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, nodeParser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+			tree, err := g.DominatorTree(files[0])
+			if err != nil {
+				return err
+			}
+
+			type dominator struct {
+				node      *graph.Node[*language.FileInfo]
+				dominated []*graph.Node[*language.FileInfo]
+				loc       int
+			}
+			var dominators []dominator
+			for _, node := range g.AllNodes() {
+				// The entrypoint trivially dominates everything.
+				if node.Id == files[0] {
+					continue
+				}
+				dominated := tree.Dominated(node.Id)
+				if len(dominated) == 0 {
+					continue
+				}
+				d := dominator{node: node, dominated: dominated}
+				for _, n := range dominated {
+					d.loc += n.Data.Loc
+				}
+				dominators = append(dominators, d)
+			}
+			slices.SortStableFunc(dominators, func(a, b dominator) int { return b.loc - a.loc })
+			if top > 0 && len(dominators) > top {
+				dominators = dominators[:top]
+			}
+
+			if len(dominators) == 0 {
+				cmd.Println("no file dominates other files apart from the entrypoint")
+				return nil
+			}
+			for _, d := range dominators {
+				noun := "files"
+				if len(d.dominated) == 1 {
+					noun = "file"
+				}
+				cmd.Printf(
+					"- %s (%d loc) dominates %d %s (%d loc):\n",
+					relPathDisplay(d.node), d.node.Data.Loc, len(d.dominated), noun, d.loc,
+				)
+				for _, n := range d.dominated {
+					cmd.Printf("  %s\n", relPathDisplay(n))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&top, "top", 20, "maximum amount of files displayed, sorted by the lines of code they dominate. 0 displays all of them")
+
+	return cmd
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 
 	"github.com/gabotechs/dep-tree/internal/config"
@@ -13,6 +15,8 @@ func EntropyCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 	var noBrowserOpen bool
 	var enableGui bool
 	var renderPath string
+	var reduce bool
+	var coChangeCommits int
 
 	cmd := &cobra.Command{
 		Use:     "entropy",
@@ -34,12 +38,28 @@ func EntropyCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 			}
 			parser := language.NewParser(lang)
 			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
+			var changeSets [][]string
+			if coChangeCommits > 0 {
+				if cfg.GroupBy != "" {
+					return errors.New("--co-change is not supported together with --group-by, as git tracks changes in files")
+				}
+				if changeSets, err = loadChangeSets(coChangeCommits); err != nil {
+					return err
+				}
+			}
 
-			err = entropy.Render(files, parser, entropy.RenderConfig{
+			err = entropy.Render(files, nodeParser, entropy.RenderConfig{
 				NoOpen:        noBrowserOpen,
 				EnableGui:     enableGui,
 				LoadCallbacks: graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay),
 				RenderPath:    renderPath,
+				Reduce:        reduce,
+				ChangeSets:    changeSets,
+				CoChange:      defaultCoChangeOptions,
 			})
 			return err
 		},
@@ -48,6 +68,8 @@ func EntropyCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 	cmd.Flags().BoolVar(&noBrowserOpen, "no-browser-open", false, "Disable the automatic browser open while rendering entropy")
 	cmd.Flags().BoolVar(&enableGui, "enable-gui", false, "Enables a GUI for changing rendering settings")
 	cmd.Flags().StringVar(&renderPath, "render-path", "", "Sets the output path of the rendered html file")
+	cmd.Flags().BoolVar(&reduce, "reduce", false, "Only render the dependencies that are not already implied by longer paths")
+	cmd.Flags().IntVar(&coChangeCommits, "co-change", 0, "Include the files that often changed together in the last commits of the git history")
 
 	return cmd
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"slices"
 	"strings"
@@ -64,8 +65,17 @@ func ExplainCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 			tempCfg.EnsureAbsPaths()
 			parser.Include = tempCfg.Only
 
+			nodeParser, _, err := groupFiles(parser, append(slices.Clone(fromFiles), toFiles...), cfg)
+			if err != nil {
+				return err
+			}
+			grouped, isGrouped := nodeParser.(*language.GroupedParser)
+			if isGrouped {
+				fromFiles, toFiles = grouped.Groups(fromFiles), grouped.Groups(toFiles)
+			}
+
 			deps, err := explain.Explain[*language.FileInfo](
-				parser,
+				nodeParser,
 				fromFiles,
 				toFiles,
 				graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay),
@@ -79,18 +89,27 @@ func ExplainCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 
 			rendered := make([]string, len(deps))
 			for i, r := range deps {
+				from, to := relPathDisplay(r.From), relPathDisplay(r.To)
 				if shouldIncludePackagePrefix {
-					fromPkg := r[0].Data.Package
+					fromPkg := r.From.Data.Package
 					if strings.HasPrefix(fromPkg, "@") {
 						fromPkg = fromPkg[1:]
 					}
-					toPkg := r[1].Data.Package
+					toPkg := r.To.Data.Package
 					if strings.HasPrefix(toPkg, "@") {
 						toPkg = toPkg[1:]
 					}
-					rendered[i] = fromPkg + "@" + relPathDisplay(r[0]) + " -> " + toPkg + "@" + relPathDisplay(r[1])
-				} else {
-					rendered[i] = relPathDisplay(r[0]) + " -> " + relPathDisplay(r[1])
+					from, to = fromPkg+"@"+from, toPkg+"@"+to
+				}
+				dep, _ := r.Data.(*language.Dependency)
+				if dep != nil && dep.Line() > 0 {
+					from += fmt.Sprintf(":%d", dep.Line())
+				}
+				rendered[i] = from + " -> " + to + describeDependency(dep)
+				if isGrouped {
+					if weight := grouped.Weight(r.From.Id, r.To.Id); weight > 1 {
+						rendered[i] += fmt.Sprintf(" (%d imports)", weight)
+					}
 				}
 			}
 
@@ -108,10 +127,25 @@ func ExplainCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 	return cmd
 }
 
-func moreThanOnePackage(deps [][2]*graph.Node[*language.FileInfo]) bool {
+// describeDependency renders what is imported in a dependency and how, like " (foo, bar) [type-only]".
+func describeDependency(dep *language.Dependency) string {
+	if dep == nil {
+		return ""
+	}
+	var result string
+	if symbols := dep.Imported(); len(symbols) > 0 {
+		result += " (" + strings.Join(symbols, ", ") + ")"
+	}
+	if kinds := dep.Kinds(); len(kinds) > 0 {
+		result += " [" + strings.Join(kinds, ", ") + "]"
+	}
+	return result
+}
+
+func moreThanOnePackage(deps []explain.Dependency[*language.FileInfo]) bool {
 	packages := map[string]struct{}{}
-	for _, nodes := range deps {
-		for _, node := range nodes {
+	for _, dep := range deps {
+		for _, node := range []*graph.Node[*language.FileInfo]{dep.From, dep.To} {
 			if _, ok := packages[node.Data.Package]; !ok {
 				packages[node.Data.Package] = struct{}{}
 				if len(packages) > 1 {
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/dot"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/spf13/cobra"
+)
+
+func GraphCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var format string
+	var clusterBySpec string
+
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Exports the whole dependency graph, for example, for rendering it with Graphviz",
+		Long: `Exports the whole dependency graph reachable from the entrypoints, unlike the tree command,
+which only displays it as a tree. With the "dot" format, the output can be rendered with
+Graphviz, for example, with:
+
+  dep-tree graph src/index.ts --format dot | dot -Tsvg > graph.svg
+
+Files are clustered by --cluster-by, the dependencies that are part of a cycle are drawn in
+red, and files have their lines of code in the "loc" attribute. Files with errors are drawn in
+red too, with the errors in their tooltip.`,
+		GroupID: renderGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "dot" {
+				return fmt.Errorf(`invalid format "%s", allowed values are "dot"`, format)
+			}
+			var clusterBy language.GroupBy
+			if clusterBySpec != "none" {
+				var err error
+				if clusterBy, err = language.ParseGroupBy(clusterBySpec); err != nil {
+					return err
+				}
+			}
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, nodeParser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+			cmd.Print(dot.Render(g, relPathDisplay, clusterBy))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "dot", `output format, currently only "dot"`)
+	cmd.Flags().StringVar(&clusterBySpec, "cluster-by", "package", `how files are clustered: "package", "dir", "dir:<depth>" or "none"`)
+
+	return cmd
+}
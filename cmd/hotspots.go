@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/hotspots"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/revision"
+	"github.com/spf13/cobra"
+)
+
+type hotspotFile struct {
+	File         string  `json:"file"`
+	Score        float64 `json:"score"`
+	Commits      int     `json:"commits"`
+	LinesChanged int     `json:"linesChanged"`
+	Loc          int     `json:"loc"`
+	FanIn        int     `json:"fanIn"`
+}
+
+func HotspotsCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var commits int
+	var sinceDate string
+	var top int
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "hotspots",
+		Short: "Ranks the files that change often, are big and are depended on by a lot of files",
+		Long: `Ranks the files by how risky they are to change, combining how much they changed in the git
+history with their size and the amount of files that depend on them. The score is the product of:
+
+  Churn:  the commits and the lines changed in the file, relative to the most changed file.
+  Size:   the lines of code of the file, relative to the biggest file.
+  Fan-in: the files that depend on the file plus one, relative to the most depended on file.
+
+Files that did not change in the analyzed commits are not listed.`,
+		GroupID: explainGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" && format != "csv" && format != "markdown" {
+				return fmt.Errorf(`invalid format "%s", allowed values are "text", "json", "csv" or "markdown"`, format)
+			}
+			if commits <= 0 {
+				return fmt.Errorf("--commits must be a positive integer, got %d", commits)
+			}
+			var since time.Time
+			if sinceDate != "" {
+				var err error
+				if since, err = time.Parse(time.DateOnly, sinceDate); err != nil {
+					return fmt.Errorf(`invalid date "%s" for --since, it must be formatted like 2006-01-02`, sinceDate)
+				}
+			}
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the hotspots command, as git tracks changes in files")
+			}
+			cwd, _ := os.Getwd()
+			repo, err := revision.Open(cwd)
+			if err != nil {
+				return err
+			}
+			churn, err := repo.Churn(commits, since)
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, parser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			ranked := hotspots.Rank(g, churn)
+			if top > 0 && len(ranked) > top {
+				ranked = ranked[:top]
+			}
+			result := make([]hotspotFile, len(ranked))
+			for i, h := range ranked {
+				result[i] = hotspotFile{
+					File:         relPathDisplay(h.File),
+					Score:        h.Score,
+					Commits:      h.Commits,
+					LinesChanged: h.LinesChanged,
+					Loc:          h.Loc,
+					FanIn:        h.FanIn,
+				}
+			}
+
+			switch format {
+			case "json":
+				rendered, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(rendered))
+				return nil
+			case "csv":
+				w := csv.NewWriter(cmd.OutOrStdout())
+				_ = w.Write([]string{"file", "score", "commits", "linesChanged", "loc", "fanIn"})
+				for _, h := range result {
+					_ = w.Write([]string{
+						h.File,
+						strconv.FormatFloat(h.Score, 'f', -1, 64),
+						strconv.Itoa(h.Commits),
+						strconv.Itoa(h.LinesChanged),
+						strconv.Itoa(h.Loc),
+						strconv.Itoa(h.FanIn),
+					})
+				}
+				w.Flush()
+				return w.Error()
+			case "markdown":
+				sb := strings.Builder{}
+				sb.WriteString("| File | Score | Commits | Lines changed | LOC | Fan-in |\n")
+				sb.WriteString("|---|---|---|---|---|---|\n")
+				for _, h := range result {
+					sb.WriteString(fmt.Sprintf("| `%s` | %.4f | %d | %d | %d | %d |\n", h.File, h.Score, h.Commits, h.LinesChanged, h.Loc, h.FanIn))
+				}
+				cmd.Print(sb.String())
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "FILE\tSCORE\tCOMMITS\tLINES CHANGED\tLOC\tFAN-IN")
+			for _, h := range result {
+				_, _ = fmt.Fprintf(w, "%s\t%.4f\t%d\t%d\t%d\t%d\n", h.File, h.Score, h.Commits, h.LinesChanged, h.Loc, h.FanIn)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&commits, "commits", 500, "amount of commits from HEAD that are analyzed")
+	cmd.Flags().StringVar(&sinceDate, "since", "", "only analyze the commits made after this date, like 2024-01-31")
+	cmd.Flags().IntVar(&top, "top", 20, "maximum amount of files displayed. 0 displays all of them")
+	cmd.Flags().StringVar(&format, "format", "text", `output format: "text", "json", "csv" or "markdown"`)
+
+	return cmd
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/metrics"
+	"github.com/spf13/cobra"
+)
+
+func MetricsCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var groupBySpec string
+	var jsonFormat bool
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Computes coupling, instability and abstractness metrics for each package",
+		Long: `Groups files into packages and computes the package metrics described by Robert C. Martin:
+
+  Ca: afferent coupling, files outside the package that depend on it.
+  Ce: efferent coupling, files outside the package that it depends on.
+  I:  instability, Ce / (Ca + Ce).
+  A:  abstractness, ratio of interfaces, traits or abstract classes over all the exported
+      types. Only available for languages that can tell them apart.
+  D:  distance from the main sequence, |A + I - 1|.`,
+		GroupID: explainGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupBy, err := language.ParseGroupBy(groupBySpec)
+			if err != nil {
+				return err
+			}
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the metrics command, use --package-by")
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, parser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			result := metrics.Compute(g, groupBy)
+			if jsonFormat {
+				rendered, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(rendered))
+				return nil
+			}
+
+			optional := func(v *float64) string {
+				if v == nil {
+					return "-"
+				}
+				return fmt.Sprintf("%.2f", *v)
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "GROUP\tFILES\tCa\tCe\tI\tA\tD")
+			for _, m := range result {
+				_, _ = fmt.Fprintf(
+					w, "%s\t%d\t%d\t%d\t%.2f\t%s\t%s\n",
+					m.Group, m.Files, m.Afferent, m.Efferent, m.Instability, optional(m.Abstractness), optional(m.Distance),
+				)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&groupBySpec, "package-by", "dir", `how files are grouped into packages: "package", "dir" or "dir:<depth>"`)
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "render the metrics in a machine readable json format")
+
+	return cmd
+}
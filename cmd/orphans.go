@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/cpp"
+	"github.com/gabotechs/dep-tree/internal/dummy"
+	golang "github.com/gabotechs/dep-tree/internal/go"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/js"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/orphans"
+	"github.com/gabotechs/dep-tree/internal/python"
+	"github.com/gabotechs/dep-tree/internal/rust"
+	"github.com/gabotechs/dep-tree/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var defaultTestPatterns = []string{
+	"**/*_test.*",
+	"**/test_*.py",
+	"**/*.test.*",
+	"**/*.spec.*",
+	"**/test/**",
+	"**/tests/**",
+	"**/__tests__/**",
+}
+
+func OrphansCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var scan []string
+	var testPatterns []string
+
+	cmd := &cobra.Command{
+		Use:   "orphans",
+		Short: "Lists the files that are not reachable from the entrypoints",
+		Long: `Lists the source files matched by --scan that are not reachable from the entrypoints,
+and separately the ones that are only reachable from test files. Files are considered
+tests if they match any of the --tests patterns.`,
+		GroupID: checkGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the orphans command, as it looks for unused files")
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			extensions := langExtensions(lang)
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+
+			patterns := make([]string, len(scan))
+			for i, pattern := range scan {
+				// Directories are scanned recursively.
+				if utils.DirExists(pattern) {
+					pattern = filepath.Join(pattern, "**")
+				}
+				patterns[i] = pattern
+			}
+			scanned, err := filesFromArgs(patterns)
+			if err != nil {
+				return err
+			}
+			scanned = slices.DeleteFunc(scanned, func(file string) bool {
+				ext := strings.TrimPrefix(filepath.Ext(file), ".")
+				return !slices.Contains(extensions, ext) || parser.ShouldExclude(file)
+			})
+
+			cwd, _ := os.Getwd()
+			absTestPatterns := make([]string, len(testPatterns))
+			for i, pattern := range testPatterns {
+				if filepath.IsAbs(pattern) {
+					absTestPatterns[i] = pattern
+				} else {
+					absTestPatterns[i] = filepath.Join(cwd, pattern)
+				}
+			}
+			var tests []string
+			for _, file := range scanned {
+				if slices.Contains(files, file) {
+					continue
+				}
+				for _, pattern := range absTestPatterns {
+					if ok, _ := utils.GlobstarMatch(pattern, file); ok {
+						tests = append(tests, file)
+						break
+					}
+				}
+			}
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(append(slices.Clone(files), tests...), parser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+			report := orphans.Find(g, scanned, files, tests)
+
+			display := func(file string) string {
+				node := g.Get(file)
+				if node == nil {
+					node, _ = parser.Node(file)
+				}
+				if node != nil {
+					return relPathDisplay(node)
+				}
+				if rel, err := filepath.Rel(cwd, file); err == nil {
+					return rel
+				}
+				return file
+			}
+			if len(report.Unreachable) == 0 && len(report.TestOnly) == 0 {
+				cmd.Println("all the scanned files are reachable from the entrypoints")
+				return nil
+			}
+			if len(report.Unreachable) > 0 {
+				cmd.Printf("found %s not reachable from the entrypoints:\n", pluralize(len(report.Unreachable), "file"))
+				for _, file := range report.Unreachable {
+					cmd.Printf("- %s\n", display(file))
+				}
+			}
+			if len(report.TestOnly) > 0 {
+				if len(report.Unreachable) > 0 {
+					cmd.Println()
+				}
+				cmd.Printf("found %s only reachable from tests:\n", pluralize(len(report.TestOnly), "file"))
+				for _, file := range report.TestOnly {
+					cmd.Printf("- %s\n", display(file))
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&scan, "scan", nil, "glob pattern or directory matching the files that should be reachable. You can provide an arbitrary number of --scan flags.")
+	cmd.Flags().StringArrayVar(&testPatterns, "tests", defaultTestPatterns, "glob pattern matching the test files, which are taken as entrypoints but reported separately.")
+	_ = cmd.MarkFlagRequired("scan")
+
+	return cmd
+}
+
+// langExtensions returns the file extensions handled by lang.
+func langExtensions(lang language.Language) []string {
+	switch lang.(type) {
+	case *js.Language:
+		return js.Extensions
+	case *rust.Language:
+		return rust.Extensions
+	case *python.Language:
+		return python.Extensions
+	case *golang.Language:
+		return golang.Extensions
+	case *dummy.Language:
+		return dummy.Extensions
+	case *cpp.Language:
+		return cpp.Extensions
+	default:
+		return nil
+	}
+}
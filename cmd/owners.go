@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/owners"
+	"github.com/spf13/cobra"
+)
+
+type ownersCrossing struct {
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	FromOwners []string `json:"fromOwners"`
+	ToOwners   []string `json:"toOwners"`
+	Line       int      `json:"line,omitempty"`
+}
+
+func OwnersCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var codeOwnersPath string
+	var jsonFormat bool
+
+	cmd := &cobra.Command{
+		Use:   "owners",
+		Short: "Lists the dependencies that cross the boundaries between the code owners",
+		Long: `Reads the CODEOWNERS file and lists the dependencies between files that do not share any owner,
+grouped by the owners on each side. Files without owners are not taken into account.
+
+The CODEOWNERS file is looked for in the .github, root and docs directories of the folder
+where the configuration file is, and of its parents.`,
+		GroupID: checkGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the owners command, as files are owned individually")
+			}
+			codeOwners, err := loadCodeOwners(codeOwnersPath, cfg)
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, parser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			crossings := make([]ownersCrossing, 0)
+			for _, crossing := range codeOwners.Crossings(g) {
+				crossings = append(crossings, ownersCrossing{
+					From:       relPathDisplay(crossing.From),
+					To:         relPathDisplay(crossing.To),
+					FromOwners: crossing.FromOwners,
+					ToOwners:   crossing.ToOwners,
+					Line:       crossing.Line,
+				})
+			}
+			if jsonFormat {
+				rendered, err := json.MarshalIndent(crossings, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(rendered))
+				return nil
+			}
+
+			if len(crossings) == 1 {
+				cmd.Println("found 1 dependency across code owners")
+			} else {
+				cmd.Printf("found %d dependencies across code owners\n", len(crossings))
+			}
+			var keys []string
+			byOwners := map[string][]ownersCrossing{}
+			for _, crossing := range crossings {
+				key := strings.Join(crossing.FromOwners, " ") + " -> " + strings.Join(crossing.ToOwners, " ")
+				if _, ok := byOwners[key]; !ok {
+					keys = append(keys, key)
+				}
+				byOwners[key] = append(byOwners[key], crossing)
+			}
+			// The owners with more dependencies between them come first.
+			slices.SortStableFunc(keys, func(a, b string) int {
+				return cmp.Compare(len(byOwners[b]), len(byOwners[a]))
+			})
+			for _, key := range keys {
+				cmd.Printf("\n%s (%d):\n", key, len(byOwners[key]))
+				for _, crossing := range byOwners[key] {
+					from := crossing.From
+					if crossing.Line > 0 {
+						from += fmt.Sprintf(":%d", crossing.Line)
+					}
+					cmd.Printf("- %s -> %s\n", from, crossing.To)
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&codeOwnersPath, "codeowners", "", "path to the CODEOWNERS file. (default looked for in the usual locations)")
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "render the dependencies in a machine readable json format")
+
+	return cmd
+}
+
+// loadCodeOwners reads the CODEOWNERS file in path, or looks for it from the directory of the
+// configuration file if path is empty.
+func loadCodeOwners(path string, cfg *config.Config) (*owners.CodeOwners, error) {
+	if path != "" {
+		return owners.Load(path)
+	}
+	codeOwners, err := owners.Find(cfg.Path)
+	if err != nil {
+		return nil, err
+	} else if codeOwners == nil {
+		return nil, fmt.Errorf("could not find a CODEOWNERS file in %s or any of its parent directories", cfg.Path)
+	}
+	return codeOwners, nil
+}
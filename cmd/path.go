@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/tree"
+	"github.com/spf13/cobra"
+)
+
+func PathCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var all bool
+	var longest bool
+	var maxPaths int
+
+	cmd := &cobra.Command{
+		Use:     "path",
+		Short:   "Shows how one file transitively ends up depending on another one",
+		GroupID: explainGroupId,
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all && longest {
+				return errors.New("only one of --all or --longest can be used at a time")
+			}
+			if maxPaths <= 0 {
+				return fmt.Errorf("--max-paths must be a positive integer, got %d", maxPaths)
+			}
+			var ends [2]string
+			for i, arg := range args {
+				files, err := filesFromArgs([]string{arg})
+				if err != nil {
+					return err
+				}
+				if len(files) != 1 {
+					return fmt.Errorf("a single file is expected, but %d files matched %s", len(files), arg)
+				}
+				ends[i] = files[0]
+			}
+			from, to := ends[0], ends[1]
+
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			if cfg.GroupBy != "" {
+				return errors.New("--group-by is not supported by the path command, as it displays the symbols imported between files")
+			}
+			lang, err := inferLang([]string{from}, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+			callbacks := graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay)
+
+			var g *graph.Graph[*language.FileInfo]
+			var paths [][]string
+			complete := true
+			if longest {
+				t, err := tree.NewTree[*language.FileInfo]([]string{from}, parser, relPathDisplay, callbacks)
+				if err != nil {
+					return err
+				}
+				g = t.Graph
+				path, err := t.LongestPath(to)
+				if err != nil {
+					return err
+				}
+				if path != nil {
+					paths = append(paths, path)
+				}
+			} else {
+				g = graph.NewGraph[*language.FileInfo]()
+				if err = g.Load([]string{from}, parser, callbacks); err != nil {
+					return err
+				}
+				if all {
+					paths, complete = g.SimplePaths(from, to, maxPaths)
+				} else if path := g.ShortestPath(from, to); path != nil {
+					paths = append(paths, path)
+				}
+			}
+
+			if len(paths) == 0 {
+				cmd.Printf("%s does not depend on %s\n", args[0], args[1])
+				return nil
+			}
+			for i, path := range paths {
+				if i > 0 {
+					cmd.Println()
+				}
+				cmd.Println(relPathDisplay(g.Get(path[0])))
+				for j := 1; j < len(path); j++ {
+					symbols, importsAll, err := parser.ImportedSymbols(path[j-1], path[j])
+					if err != nil {
+						return err
+					}
+					imported := relPathDisplay(g.Get(path[j]))
+					switch {
+					case importsAll:
+						cmd.Printf("  imports everything from %s\n", imported)
+					case len(symbols) > 0:
+						cmd.Printf("  imports %s from %s\n", strings.Join(symbols, ", "), imported)
+					default:
+						cmd.Printf("  imports %s\n", imported)
+					}
+				}
+			}
+			if !complete {
+				cmd.Printf("\nstopped after %d paths, there might be more\n", len(paths))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "show all the paths that do not go through the same file twice, instead of only the shortest one")
+	cmd.Flags().BoolVar(&longest, "longest", false, "show the longest path, ignoring the dependencies that close circular dependencies")
+	cmd.Flags().IntVar(&maxPaths, "max-paths", 10, "maximum amount of paths displayed with --all")
+
+	return cmd
+}
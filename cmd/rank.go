@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/spf13/cobra"
+)
+
+type rankedFile struct {
+	File        string  `json:"file"`
+	PageRank    float64 `json:"pageRank"`
+	Betweenness float64 `json:"betweenness"`
+	Loc         int     `json:"loc"`
+	FanIn       int     `json:"fanIn"`
+	FanOut      int     `json:"fanOut"`
+}
+
+func RankCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	var top int
+	var by string
+	var jsonFormat bool
+
+	cmd := &cobra.Command{
+		Use:   "rank",
+		Short: "Ranks the files by how central they are in the dependency graph",
+		Long: `Ranks the files by how central they are in the dependency graph:
+
+  PageRank:    higher for the files that are depended on by a lot of files, specially if
+               those are also depended on by a lot of files.
+  Betweenness: amount of shortest paths between any other two files that go through the file.`,
+		GroupID: explainGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var score func(c graph.Centrality) float64
+			switch by {
+			case "pagerank":
+				score = func(c graph.Centrality) float64 { return c.PageRank }
+			case "betweenness":
+				score = func(c graph.Centrality) float64 { return c.Betweenness }
+			default:
+				return fmt.Errorf(`invalid value "%s" for --by, allowed values are "pagerank" or "betweenness"`, by)
+			}
+
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, nodeParser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+
+			centrality := g.Centrality()
+			nodes := g.AllNodes()
+			slices.SortStableFunc(nodes, func(a, b *graph.Node[*language.FileInfo]) int {
+				// Rounded for avoiding ties being broken by floating point noise.
+				return cmp.Compare(roundCentrality(score(centrality[b.Id])), roundCentrality(score(centrality[a.Id])))
+			})
+			if top > 0 && len(nodes) > top {
+				nodes = nodes[:top]
+			}
+
+			ranked := make([]rankedFile, len(nodes))
+			for i, node := range nodes {
+				ranked[i] = rankedFile{
+					File:        relPathDisplay(node),
+					PageRank:    roundCentrality(centrality[node.Id].PageRank),
+					Betweenness: roundCentrality(centrality[node.Id].Betweenness),
+					Loc:         node.Data.Loc,
+					FanIn:       len(g.ToId(node.Id)),
+					FanOut:      len(g.FromId(node.Id)),
+				}
+			}
+
+			if jsonFormat {
+				rendered, err := json.MarshalIndent(ranked, "", "  ")
+				if err != nil {
+					return err
+				}
+				cmd.Println(string(rendered))
+				return nil
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "FILE\tPAGERANK\tBETWEENNESS\tLOC\tFAN-IN\tFAN-OUT")
+			for _, r := range ranked {
+				_, _ = fmt.Fprintf(w, "%s\t%.4f\t%.2f\t%d\t%d\t%d\n", r.File, r.PageRank, r.Betweenness, r.Loc, r.FanIn, r.FanOut)
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().IntVar(&top, "top", 20, "maximum amount of files displayed. 0 displays all of them")
+	cmd.Flags().StringVar(&by, "by", "pagerank", `centrality measure used for sorting the files: "pagerank" or "betweenness"`)
+	cmd.Flags().BoolVar(&jsonFormat, "json", false, "render the ranking in a machine readable json format")
+
+	return cmd
+}
+
+// roundCentrality keeps 6 decimals, as the PageRank is approximated iteratively.
+func roundCentrality(v float64) float64 {
+	return math.Round(v*1e6) / 1e6
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/spf13/cobra"
+)
+
+func RedundantCmd(cfgF func() (*config.Config, error)) *cobra.Command {
+	return &cobra.Command{
+		Use:   "redundant",
+		Short: "Lists the direct dependencies that are already implied by longer paths",
+		Long: `Lists the direct dependencies that are already implied by longer paths, like A -> C
+when there is also A -> B -> C. Circular dependencies are removed before looking for
+redundant dependencies, so the ones that are part of a cycle are not taken into account.`,
+		GroupID: explainGroupId,
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files, err := filesFromArgs(args)
+			if err != nil {
+				return err
+			}
+			cfg, err := cfgF()
+			if err != nil {
+				return err
+			}
+			lang, err := inferLang(files, cfg)
+			if err != nil {
+				return err
+			}
+			parser := language.NewParser(lang)
+			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
+
+			g := graph.NewGraph[*language.FileInfo]()
+			err = g.Load(files, nodeParser, graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
+			if err != nil {
+				return err
+			}
+			var entrypoints []*graph.Node[*language.FileInfo]
+			for _, file := range files {
+				if node := g.Get(file); node != nil {
+					entrypoints = append(entrypoints, node)
+				}
+			}
+			g.RemoveCycles(entrypoints)
+
+			redundant, err := g.TransitiveReduction()
+			if err != nil {
+				return err
+			}
+			if len(redundant) == 0 {
+				cmd.Println("no redundant dependencies found")
+				return nil
+			}
+			for _, edge := range redundant {
+				path := make([]string, len(edge.Path))
+				for i, id := range edge.Path {
+					path[i] = relPathDisplay(g.Get(id))
+				}
+				cmd.Printf(
+					"- %s -> %s is already implied by %s\n",
+					relPathDisplay(g.Get(edge.From)),
+					relPathDisplay(g.Get(edge.To)),
+					strings.Join(path, " -> "),
+				)
+			}
+			return nil
+		},
+	}
+}
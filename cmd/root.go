@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/bmatcuk/doublestar/v4"
 	"github.com/gabotechs/dep-tree/internal/config"
@@ -25,6 +26,7 @@ const explainGroupId = "explain"
 const renderGroupId = "render"
 const checkGroupId = "check"
 const defaultCommand = "entropy"
+const version = "v0.23.4"
 
 func NewRoot(args []string) *cobra.Command {
 	if args == nil {
@@ -33,7 +35,7 @@ func NewRoot(args []string) *cobra.Command {
 
 	root := &cobra.Command{
 		Use:               "dep-tree",
-		Version:           "v0.23.4",
+		Version:           version,
 		Short:             "Visualize and check your project's dependency graph",
 		SilenceUsage:      true,
 		Args:              cobra.ArbitraryArgs,
@@ -67,11 +69,15 @@ $ dep-tree check`,
 	root.PersistentFlags().SortFlags = false
 	root.PersistentFlags().StringVarP(&fileConfigPath, "config", "c", "", "path to dep-tree's config file. (default .dep-tree.yml)")
 	root.PersistentFlags().BoolVar(&cliCfg.UnwrapExports, "unwrap-exports", false, "trace re-exported symbols to the file where they are declared. (default false)")
+	root.PersistentFlags().BoolVar(&cliCfg.IncludeExternal, "include-external", false, "include third-party packages as nodes in the graph. (default false)")
 	root.PersistentFlags().BoolVar(&cliCfg.Js.TsConfigPaths, "js-tsconfig-paths", true, "follow the tsconfig.json paths while resolving imports.")
 	root.PersistentFlags().BoolVar(&cliCfg.Js.Workspaces, "js-workspaces", true, "take the workspaces attribute in the root package.json into account for resolving paths.")
 	root.PersistentFlags().BoolVar(&cliCfg.Python.ExcludeConditionalImports, "python-exclude-conditional-imports", false, "exclude imports wrapped inside if or try statements. (default false)")
 	root.PersistentFlags().StringArrayVar(&cliCfg.Only, "only", nil, "Files that do not match this glob pattern will be ignored. You can provide an arbitrary number of --only flags.")
 	root.PersistentFlags().StringArrayVar(&cliCfg.Exclude, "exclude", nil, "Files that match this glob pattern will be ignored. You can provide an arbitrary number of --exclude flags.")
+	root.PersistentFlags().StringVar(&cliCfg.CacheDir, "cache-dir", "", "directory where parsing results are cached between runs, like .dep-tree-cache. (default disabled)")
+	root.PersistentFlags().IntVarP(&cliCfg.Jobs, "jobs", "j", runtime.NumCPU(), "maximum amount of files parsed in parallel.")
+	root.PersistentFlags().StringVar(&cliCfg.GroupBy, "group-by", "", `collapse files into groups, either "package", "dir" or "dir:<depth>". (default disabled)`)
 
 	cfgF := func() (*config.Config, error) {
 		fileCfg, err := config.ParseConfigFromFile(fileConfigPath)
@@ -103,6 +109,12 @@ $ dep-tree check`,
 		// merge the exclusions and inclusions from the CLI and from the config.
 		fileCfg.Exclude = append(fileCfg.Exclude, cliCfg.Exclude...)
 		fileCfg.Only = append(fileCfg.Only, cliCfg.Only...)
+		fileCfg.Jobs = max(cliCfg.Jobs, 1)
+		fileCfg.GroupBy = cliCfg.GroupBy
+		fileCfg.IncludeExternal = fileCfg.IncludeExternal || cliCfg.IncludeExternal
+		if cliCfg.CacheDir != "" {
+			fileCfg.CacheDir = cliCfg.CacheDir
+		}
 
 		return fileCfg, fileCfg.ValidatePatterns()
 	}
@@ -111,8 +123,21 @@ $ dep-tree check`,
 		EntropyCmd(cfgF),
 		TreeCmd(cfgF),
 		CheckCmd(cfgF),
+		CyclesCmd(cfgF),
 		ConfigCmd(cfgF),
 		ExplainCmd(cfgF),
+		RedundantCmd(cfgF),
+		MetricsCmd(cfgF),
+		DominatorsCmd(cfgF),
+		PathCmd(cfgF),
+		RankCmd(cfgF),
+		ClusterCmd(cfgF),
+		OrphansCmd(cfgF),
+		DiffCmd(cfgF),
+		CoChangeCmd(cfgF),
+		HotspotsCmd(cfgF),
+		OwnersCmd(cfgF),
+		GraphCmd(cfgF),
 	)
 
 	switch {
@@ -245,8 +270,41 @@ func filesFromArgs(args []string) ([]string, error) {
 
 func applyConfigToParser(parser *language.Parser, cfg *config.Config) {
 	parser.UnwrapProxyExports = cfg.UnwrapExports
+	parser.IncludeExternal = cfg.IncludeExternal
 	parser.Exclude = cfg.Exclude
 	parser.Include = cfg.Only
+	parser.Jobs = cfg.Jobs
+	if cfg.CacheDir != "" {
+		// The version is part of the fingerprint, as parsing might change between releases.
+		parser.Lang = language.NewDiskCache(parser.Lang, cfg.CacheDir, version+"-"+cfg.LanguageFingerprint())
+	}
+}
+
+// groupFiles wraps parser so that files are collapsed into groups if --group-by was provided.
+// It returns the parser that should be used for loading the graph and the ids of the nodes
+// where files are.
+func groupFiles(
+	parser graph.NodeParser[*language.FileInfo],
+	files []string,
+	cfg *config.Config,
+) (graph.NodeParser[*language.FileInfo], []string, error) {
+	if cfg.GroupBy == "" {
+		return parser, files, nil
+	}
+	groupBy, err := language.ParseGroupBy(cfg.GroupBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	grouped, err := language.NewGroupedParser(
+		parser,
+		groupBy,
+		files,
+		graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return grouped, grouped.Groups(files), nil
 }
 
 func relPathDisplay(node *graph.Node[*language.FileInfo]) string {
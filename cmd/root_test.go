@@ -2,15 +2,22 @@ package cmd
 
 import (
 	"bytes"
+	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 
 	"github.com/gabotechs/dep-tree/internal/config"
+	"github.com/gabotechs/dep-tree/internal/diff"
 	"github.com/gabotechs/dep-tree/internal/js"
 	"github.com/gabotechs/dep-tree/internal/language"
 	"github.com/gabotechs/dep-tree/internal/python"
+	"github.com/gabotechs/dep-tree/internal/revision"
 	"github.com/gabotechs/dep-tree/internal/rust"
 	"github.com/stretchr/testify/require"
 
@@ -65,6 +72,15 @@ func TestRoot(t *testing.T) {
 		{
 			Name: "check --config .root_test/.dep-tree.yml-bad-path",
 		},
+		{
+			Name: "check --config .root_test/explain/.dep-tree.yml",
+		},
+		{
+			Name: "check --config .root_test/external/.dep-tree.yml",
+		},
+		{
+			Name: "check --config .root_test/owners/.dep-tree.yml",
+		},
 		{
 			Name: "tree .root_test/main.py --json",
 		},
@@ -93,6 +109,27 @@ func TestRoot(t *testing.T) {
 		{
 			Name: "explain .root_test/*.py",
 		},
+		{
+			Name: "cycles .root_test/main.py",
+		},
+		{
+			Name: "redundant .root_test/redundant/a.py",
+		},
+		{
+			Name: "redundant .root_test/main.py",
+		},
+		{
+			Name: "tree .root_test/redundant/a.py --json",
+		},
+		{
+			Name: "tree .root_test/redundant/a.py --json --reduce",
+		},
+		{
+			Name: "cycles .root_test/cycles/a.py",
+		},
+		{
+			Name: "cycles .root_test/cycles/a.py --suggest",
+		},
 		{
 			Name: "explain .root_test/* ./**/dep.py",
 		},
@@ -102,6 +139,153 @@ func TestRoot(t *testing.T) {
 		{
 			Name: "explain .root_test/*.py ./**/deps.py foo.bar",
 		},
+		{
+			Name: "explain .root_test/explain/main.py .root_test/explain/*.py -l",
+		},
+		{
+			Name: "tree .root_test/external/main.py --json --include-external",
+		},
+		{
+			Name: "tree .root_test/external/main.py --json",
+		},
+		{
+			Name: "co-change .root_test/main.py --commits 0",
+		},
+		{
+			Name: "co-change .root_test/main.py --group-by dir",
+		},
+		{
+			Name: "hotspots .root_test/main.py --since 2999-01-01 --format csv",
+		},
+		{
+			Name: "hotspots .root_test/main.py --format xml",
+		},
+		{
+			Name: "hotspots .root_test/main.py --since yesterday",
+		},
+		{
+			Name: "owners .root_test/owners/main.py --config .root_test/owners/.dep-tree.yml",
+		},
+		{
+			Name: "owners .root_test/owners/main.py --codeowners .root_test/owners/.github/CODEOWNERS --json",
+		},
+		{
+			Name: "graph .root_test/cycles/a.py --format dot",
+		},
+		{
+			Name: "graph .root_test/cluster/main.py --cluster-by none",
+		},
+		{
+			Name: "graph .root_test/cluster/main.py --group-by dir",
+		},
+		{
+			Name: "graph .root_test/main.py --format svg",
+		},
+		{
+			Name: "metrics .root_test/metrics/app/main.py",
+		},
+		{
+			Name: "metrics .root_test/metrics/app/main.py --json",
+		},
+		{
+			Name: "metrics .root_test/metrics/app/main.py --package-by package",
+		},
+		{
+			Name: "metrics .root_test/metrics/app/main.py --package-by file",
+		},
+		{
+			Name: "metrics .root_test/metrics/app/main.py --group-by dir",
+		},
+		{
+			Name: "dominators .root_test/dominators/main.py",
+		},
+		{
+			Name: "dominators .root_test/dominators/main.py --top 1",
+		},
+		{
+			Name: "dominators .root_test/dominators/d.py",
+		},
+		{
+			Name: "path .root_test/path/main.py .root_test/path/c.py",
+		},
+		{
+			Name: "path .root_test/path/main.py .root_test/path/c.py --all",
+		},
+		{
+			Name: "path .root_test/path/main.py .root_test/path/c.py --all --max-paths 2",
+		},
+		{
+			Name: "path .root_test/path/main.py .root_test/path/c.py --all --max-paths 0",
+		},
+		{
+			Name: "path .root_test/path/main.py .root_test/path/c.py --longest",
+		},
+		{
+			Name: "path .root_test/path/c.py .root_test/path/main.py",
+		},
+		{
+			Name: "rank .root_test/dominators/main.py",
+		},
+		{
+			Name: "rank .root_test/dominators/main.py --by betweenness --top 3",
+		},
+		{
+			Name: "rank .root_test/dominators/main.py --json --top 2",
+		},
+		{
+			Name: "rank .root_test/dominators/main.py --by loc",
+		},
+		{
+			Name: "cluster .root_test/cluster/main.py",
+		},
+		{
+			Name: "cluster .root_test/cluster/main.py --resolution 0.1",
+		},
+		{
+			Name: "cluster .root_test/cluster/main.py --group-by dir",
+		},
+		{
+			Name: "tree .root_test/cluster/main.py --json --group-by dir",
+		},
+		{
+			Name: "tree .root_test/cluster/main.py --json --group-by file",
+		},
+		{
+			Name: "explain .root_test/cluster/users/*.py .root_test/cluster/orders/*.py --group-by dir",
+		},
+		{
+			Name: "cycles .root_test/cluster/main.py --group-by dir",
+		},
+		{
+			Name: "path .root_test/cluster/main.py .root_test/cluster/orders/db.py --group-by dir",
+		},
+		{
+			Name: "orphans .root_test/orphans/main.py --scan .root_test/orphans/**",
+		},
+		{
+			Name: "orphans .root_test/orphans/main.py --scan .root_test/orphans",
+		},
+		{
+			Name: "orphans .root_test/orphans/main.py --scan .root_test/orphans/** --exclude .root_test/orphans/generated.py",
+		},
+		{
+			Name: "orphans .root_test/orphans/main.py --scan .root_test/orphans/** --tests **/none",
+		},
+		{
+			Name: "orphans .root_test/orphans/main.py",
+		},
+		{
+			Name: "diff HEAD HEAD .root_test/main.py",
+		},
+		{
+			Name: "diff HEAD HEAD .root_test/main.py --format markdown",
+		},
+		{
+			Name: "diff HEAD HEAD .root_test/main.py --format xml",
+		},
+		{
+			Name: "diff HEAD unknown-revision .root_test/main.py",
+		},
 	}
 
 	for _, tt := range tests {
@@ -239,9 +423,22 @@ func TestFilesFromArgs(t *testing.T) {
 			Input: []string{filepath.Join("..", "cmd", "*")},
 			Expected: []string{
 				filepath.Join("cmd", "check.go"),
+				filepath.Join("cmd", "cluster.go"),
+				filepath.Join("cmd", "cochange.go"),
 				filepath.Join("cmd", "config.go"),
+				filepath.Join("cmd", "cycles.go"),
+				filepath.Join("cmd", "diff.go"),
+				filepath.Join("cmd", "dominators.go"),
 				filepath.Join("cmd", "entropy.go"),
 				filepath.Join("cmd", "explain.go"),
+				filepath.Join("cmd", "graph.go"),
+				filepath.Join("cmd", "hotspots.go"),
+				filepath.Join("cmd", "metrics.go"),
+				filepath.Join("cmd", "orphans.go"),
+				filepath.Join("cmd", "owners.go"),
+				filepath.Join("cmd", "path.go"),
+				filepath.Join("cmd", "rank.go"),
+				filepath.Join("cmd", "redundant.go"),
 				filepath.Join("cmd", "root.go"),
 				filepath.Join("cmd", "root_test.go"),
 				filepath.Join("cmd", "tree.go"),
@@ -301,3 +498,49 @@ func TestFilesFromArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadRevision_CppIncludePaths(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	a.NoError(err)
+	worktree, err := repo.Worktree()
+	a.NoError(err)
+	commit := func(files map[string]string) {
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			a.NoError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+			a.NoError(os.WriteFile(path, []byte(content), 0o644))
+			_, err = worktree.Add(name)
+			a.NoError(err)
+		}
+		_, err = worktree.Commit("commit", &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+		})
+		a.NoError(err)
+	}
+	commit(map[string]string{
+		"src/main.cpp":  "#include <dep.h>\n",
+		"include/dep.h": "#include <a.h>\n",
+		"include/a.h":   "#pragma once\n",
+		"include/b.h":   "#pragma once\n",
+	})
+	// The header found through the include path changes its includes.
+	commit(map[string]string{"include/dep.h": "#include <b.h>\n"})
+
+	cfg := &config.Config{Path: filepath.Join(dir, "src"), Source: "default"}
+	cfg.Cpp.RecursiveIncludePaths = []string{filepath.Join(dir, "include"), filepath.Join(dir, "src")}
+	rev, err := revision.Open(dir)
+	a.NoError(err)
+	entrypoints := []string{filepath.Join(dir, "src", "main.cpp")}
+	before, err := loadRevision(rev, "HEAD~1", t.TempDir(), entrypoints, cfg)
+	a.NoError(err)
+	after, err := loadRevision(rev, "HEAD", t.TempDir(), entrypoints, cfg)
+	a.NoError(err)
+
+	d := diff.Compare(before, after)
+	a.Equal([]string{"include/b.h"}, d.AddedFiles)
+	a.Equal([]string{"include/a.h"}, d.RemovedFiles)
+	a.Equal([]diff.Edge{{From: "include/dep.h", To: "include/b.h"}}, d.AddedDependencies)
+	a.Equal([]diff.Edge{{From: "include/dep.h", To: "include/a.h"}}, d.RemovedDependencies)
+}
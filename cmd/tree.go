@@ -12,6 +12,7 @@ import (
 
 func TreeCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 	var jsonFormat bool
+	var reduce bool
 
 	cmd := &cobra.Command{
 		Use:     "tree",
@@ -36,17 +37,26 @@ func TreeCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 
 			parser := language.NewParser(lang)
 			applyConfigToParser(parser, cfg)
+			nodeParser, files, err := groupFiles(parser, files, cfg)
+			if err != nil {
+				return err
+			}
 
 			if jsonFormat {
 				t, err := tree.NewTree[*language.FileInfo](
 					files,
-					parser,
+					nodeParser,
 					relPathDisplay,
 					graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay),
 				)
 				if err != nil {
 					return err
 				}
+				if reduce {
+					if _, err = t.Graph.TransitiveReduction(); err != nil {
+						return err
+					}
+				}
 
 				rendered, err := t.RenderStructured()
 				cmd.Println(rendered)
@@ -54,10 +64,11 @@ func TreeCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 			} else {
 				return tui.Loop[*language.FileInfo](
 					files,
-					parser,
+					nodeParser,
 					relPathDisplay,
 					nil,
 					true,
+					reduce,
 					nil,
 					graph.NewStdErrCallbacks[*language.FileInfo](relPathDisplay))
 			}
@@ -65,6 +76,7 @@ func TreeCmd(cfgF func() (*config.Config, error)) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&jsonFormat, "json", false, "render the dependency tree in a machine readable json format")
+	cmd.Flags().BoolVar(&reduce, "reduce", false, "only render the dependencies that are not already implied by longer paths")
 
 	return cmd
 }
@@ -2,6 +2,7 @@ package check
 
 import (
 	"errors"
+	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -9,11 +10,23 @@ import (
 	"github.com/gabotechs/dep-tree/internal/utils"
 )
 
+// maxReportedCycles is the maximum amount of cycles displayed for each group of
+// files that depend on each other.
+const maxReportedCycles = 5
+
+// Rule is an additional verification performed over the loaded graph. The violations
+// it returns are reported under Title, together with the rest of the failed checks.
+type Rule[T any] struct {
+	Title string
+	Run   func(g *graph.Graph[T]) ([]string, error)
+}
+
 func Check[T any](
 	parser graph.NodeParser[T],
 	display func(node *graph.Node[T]) string,
 	cfg *Config,
 	callbacks graph.LoadCallbacks[T],
+	rules ...Rule[T],
 ) error {
 	// 1. build the graph.
 	files := make([]string, len(cfg.Entrypoints))
@@ -28,49 +41,76 @@ func Check[T any](
 	}
 
 	// 2. Check for rule violations in the graph.
+	violations, err := Violations(g, cfg)
+	if err != nil {
+		return err
+	}
 	sb := strings.Builder{}
-	for _, node := range g.AllNodes() {
-		for _, dep := range g.FromId(node.Id) {
-			from, to := cfg.rel(node.Id), cfg.rel(dep.Id)
-			pass, reason, err := cfg.Check(from, to)
-			if err != nil {
-				return err
-			} else if !pass {
-				sb.WriteString("- ")
-				sb.WriteString(from)
-				sb.WriteString(" -> ")
-				sb.WriteString(to)
-				if reason != "" {
-					for _, line := range strings.Split(reason, "\n") {
-						sb.WriteString("\n  ")
-						sb.WriteString(line)
-					}
-				}
-				sb.WriteString("\n")
+	for _, violation := range violations {
+		sb.WriteString("- ")
+		sb.WriteString(violation.From)
+		if violation.Line > 0 {
+			sb.WriteString(fmt.Sprintf(":%d", violation.Line))
+		}
+		sb.WriteString(" -> ")
+		sb.WriteString(violation.To)
+		if violation.Reason != "" {
+			for _, line := range strings.Split(violation.Reason, "\n") {
+				sb.WriteString("\n  ")
+				sb.WriteString(line)
 			}
 		}
+		sb.WriteString("\n")
 	}
-	// 3. Check for cycles.
-	cycles := g.RemoveElementaryCycles()
+	// 3. Check the additional rules, before cycles are removed from the graph.
+	for _, rule := range rules {
+		violations, err := rule.Run(g)
+		if err != nil {
+			return err
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		sb.WriteString("\n")
+		sb.WriteString(rule.Title)
+		sb.WriteString(":\n")
+		for _, violation := range violations {
+			sb.WriteString("- ")
+			sb.WriteString(strings.ReplaceAll(violation, "\n", "\n  "))
+			sb.WriteString("\n")
+		}
+	}
+	// 4. Check for cycles.
 	if !cfg.AllowCircularDependencies {
-		if len(cycles) > 0 {
+		components := g.CyclicComponents(maxReportedCycles)
+		if len(components) > 0 {
 			sb.WriteString("\n")
 			sb.WriteString("detected circular dependencies:")
 			sb.WriteString("\n")
 		}
-		for _, cycle := range cycles {
-			formattedCycleStack := make([]string, len(cycle.Stack))
-			for i, el := range cycle.Stack {
-				if node := g.Get(el); node != nil {
-					formattedCycleStack[i] = display(node)
+		format := func(ids []string) []string {
+			result := make([]string, len(ids))
+			for i, id := range ids {
+				if node := g.Get(id); node != nil {
+					result[i] = display(node)
 				} else {
-					formattedCycleStack[i] = el
+					result[i] = id
 				}
 			}
-
+			return result
+		}
+		for _, component := range components {
 			sb.WriteString("- ")
-			sb.WriteString(strings.Join(formattedCycleStack, " -> "))
+			if len(component.Nodes) > 1 {
+				sb.WriteString(fmt.Sprintf("%d files depend on each other: ", len(component.Nodes)))
+			}
+			sb.WriteString(strings.Join(format(component.Nodes), ", "))
 			sb.WriteString("\n")
+			for _, cycle := range component.Cycles {
+				sb.WriteString("  ")
+				sb.WriteString(strings.Join(format(cycle), " -> "))
+				sb.WriteString("\n")
+			}
 		}
 	}
 	errorMsg := sb.String()
@@ -80,6 +120,39 @@ func Check[T any](
 	return nil
 }
 
+// Violation is a dependency that breaks the allow or deny rules of the configuration.
+type Violation struct {
+	// From is the path of the file that depends on To, relative to the configuration.
+	From string `json:"from"`
+	// To is the path of the dependency, relative to the configuration.
+	To string `json:"to"`
+	// Reason is the explanation given in the rule that was broken, if any.
+	Reason string `json:"reason,omitempty"`
+	// Line is the line in From where To is imported, or 0 if it is not known.
+	Line int `json:"line,omitempty"`
+}
+
+// Violations returns the dependencies in g that break the allow or deny rules in cfg.
+func Violations[T any](g *graph.Graph[T], cfg *Config) ([]Violation, error) {
+	var result []Violation
+	for _, node := range g.AllNodes() {
+		for _, dep := range g.FromId(node.Id) {
+			from, to := cfg.rel(node.Id), cfg.rel(dep.Id)
+			pass, reason, err := cfg.Check(from, to)
+			if err != nil {
+				return nil, err
+			} else if !pass {
+				violation := Violation{From: from, To: to, Reason: reason}
+				if data, ok := g.EdgeData(node.Id, dep.Id).(graph.LineData); ok {
+					violation.Line = data.Line()
+				}
+				result = append(result, violation)
+			}
+		}
+	}
+	return result, nil
+}
+
 func (c *Config) whiteListCheck(from, to string) (bool, string, error) {
 	for k, rule := range c.WhiteList {
 		doesMatch, err := utils.GlobstarMatch(k, from)
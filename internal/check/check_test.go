@@ -39,7 +39,8 @@ Check failed, the following dependencies are not allowed:
 - 4 -> 3
 
 detected circular dependencies:
-- 4 -> 3 -> 4`,
+- 2 files depend on each other: 3, 4
+  3 -> 4 -> 3`,
 		},
 		{
 			Name: "With description",
@@ -67,7 +68,32 @@ Check failed, the following dependencies are not allowed:
   4 Should not be importing anything
 
 detected circular dependencies:
-- 4 -> 3 -> 4`,
+- 2 files depend on each other: 3, 4
+  3 -> 4 -> 3`,
+		},
+		{
+			Name: "Multiple cycles",
+			Spec: [][]int{
+				0: {1, 4},
+				1: {2, 3},
+				2: {1, 3},
+				3: {1},
+				4: {5},
+				5: {4},
+			},
+			Config: &Config{
+				Entrypoints: []string{"0"},
+			},
+			Failure: `
+Check failed, the following dependencies are not allowed:
+
+detected circular dependencies:
+- 3 files depend on each other: 1, 2, 3
+  1 -> 2 -> 1
+  1 -> 3 -> 1
+  2 -> 3 -> 1 -> 2
+- 2 files depend on each other: 4, 5
+  4 -> 5 -> 4`,
 		},
 	}
 
@@ -90,3 +116,70 @@ detected circular dependencies:
 		})
 	}
 }
+
+func TestCheck_Rules(t *testing.T) {
+	a := require.New(t)
+
+	err := Check[[]int](
+		&graph.TestParser{Spec: [][]int{0: {1}, 1: {2}, 2: {}}},
+		func(node *graph.Node[[]int]) string { return node.Id },
+		&Config{Entrypoints: []string{"0"}},
+		nil,
+		Rule[[]int]{
+			Title: "nodes with dependencies",
+			Run: func(g *graph.Graph[[]int]) ([]string, error) {
+				var result []string
+				for _, node := range g.AllNodes() {
+					if deps := g.FromId(node.Id); len(deps) > 0 {
+						result = append(result, node.Id+"\ndepends on "+deps[0].Id)
+					}
+				}
+				return result, nil
+			},
+		},
+		Rule[[]int]{
+			Title: "passing rule",
+			Run:   func(g *graph.Graph[[]int]) ([]string, error) { return nil, nil },
+		},
+	)
+	a.Equal(strings.TrimSpace(`
+Check failed, the following dependencies are not allowed:
+
+nodes with dependencies:
+- 0
+  depends on 1
+- 1
+  depends on 2`), strings.TrimSpace(err.Error()))
+}
+
+func TestViolations(t *testing.T) {
+	a := require.New(t)
+	g := graph.MakeTestGraph([][]int{
+		0: {1, 2},
+		1: {2},
+		2: {},
+	})
+	violations, err := Violations(g, &Config{
+		BlackList: map[string][]BlackListEntry{
+			"*": {{To: "2", Reason: "2 is private"}},
+		},
+	})
+	a.NoError(err)
+	a.Equal([]Violation{
+		{From: "0", To: "2", Reason: "2 is private"},
+		{From: "1", To: "2", Reason: "2 is private"},
+	}, violations)
+
+	a.NoError(g.SetEdgeData("1", "2", testLineData(4)))
+	violations, err = Violations(g, &Config{
+		BlackList: map[string][]BlackListEntry{
+			"1": {{To: "2"}},
+		},
+	})
+	a.NoError(err)
+	a.Equal([]Violation{{From: "1", To: "2", Line: 4}}, violations)
+}
+
+type testLineData int
+
+func (t testLineData) Line() int { return int(t) }
@@ -1,5 +1,7 @@
 package check
 
+import "slices"
+
 type Config struct {
 	Path                      string
 	Entrypoints               []string                    `yaml:"entrypoints"`
@@ -7,6 +9,45 @@ type Config struct {
 	Aliases                   map[string][]string         `yaml:"aliases"`
 	WhiteList                 map[string]WhiteListEntries `yaml:"allow"`
 	BlackList                 map[string][]BlackListEntry `yaml:"deny"`
+	Metrics                   MetricsThresholds           `yaml:"metrics"`
+	Owners                    map[string]OwnersRule       `yaml:"owners"`
+}
+
+// OwnersRule restricts which code owners, as declared in the CODEOWNERS file, the files of an
+// owner can depend on. Files without owners, or also owned by the same owner, are always allowed.
+type OwnersRule struct {
+	// Allow are the only owners that can be depended on, if not empty.
+	Allow []string `yaml:"allow"`
+	// Deny are the owners that cannot be depended on.
+	Deny   []string `yaml:"deny"`
+	Reason string   `yaml:"reason"`
+}
+
+// Allows tells whether a file can depend on a file owned by owners.
+func (r OwnersRule) Allows(owners []string) bool {
+	for _, owner := range owners {
+		if slices.Contains(r.Deny, owner) {
+			return false
+		}
+	}
+	if len(r.Allow) == 0 {
+		return true
+	}
+	for _, owner := range owners {
+		if slices.Contains(r.Allow, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// MetricsThresholds are the limits for the package metrics computed by `dep-tree metrics`.
+type MetricsThresholds struct {
+	// GroupBy determines how files are grouped into packages, "dir" by default.
+	GroupBy string `yaml:"groupBy"`
+	// MaxDistance is the maximum distance from the main sequence that a group can have.
+	// It is disabled if 0.
+	MaxDistance float64 `yaml:"maxDistance"`
 }
 
 func (c *Config) Init(path string) {
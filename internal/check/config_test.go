@@ -81,3 +81,54 @@ func TestConfig_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestOwnersRule_Allows(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Rule    OwnersRule
+		Owners  []string
+		Allowed bool
+	}{
+		{
+			Name:    "no restrictions",
+			Owners:  []string{"@team-b"},
+			Allowed: true,
+		},
+		{
+			Name:    "denied",
+			Rule:    OwnersRule{Deny: []string{"@team-b"}},
+			Owners:  []string{"@team-c", "@team-b"},
+			Allowed: false,
+		},
+		{
+			Name:    "not denied",
+			Rule:    OwnersRule{Deny: []string{"@team-b"}},
+			Owners:  []string{"@team-c"},
+			Allowed: true,
+		},
+		{
+			Name:    "allowed",
+			Rule:    OwnersRule{Allow: []string{"@team-c"}},
+			Owners:  []string{"@team-b", "@team-c"},
+			Allowed: true,
+		},
+		{
+			Name:    "not allowed",
+			Rule:    OwnersRule{Allow: []string{"@team-c"}},
+			Owners:  []string{"@team-b"},
+			Allowed: false,
+		},
+		{
+			Name:    "deny takes precedence",
+			Rule:    OwnersRule{Allow: []string{"@team-c"}, Deny: []string{"@team-b"}},
+			Owners:  []string{"@team-b", "@team-c"},
+			Allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			require.Equal(t, tt.Allowed, tt.Rule.Allows(tt.Owners))
+		})
+	}
+}
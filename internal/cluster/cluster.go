@@ -0,0 +1,120 @@
+package cluster
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// Misplaced is a file that ended up in a different cluster than most of the files in its group.
+type Misplaced struct {
+	File *graph.Node[*language.FileInfo]
+	// Group is where the file currently is.
+	Group string
+	// Suggested is the group where most of the files in the file's cluster are.
+	Suggested string
+}
+
+// Report compares the clusters found in the graph with the current layout of the files.
+type Report struct {
+	// Clusters are the groups of files that depend much more on each other than on the rest.
+	Clusters [][]*graph.Node[*language.FileInfo]
+	// Modularity of the clusters, from -0.5 to 1. The higher, the better the files are split.
+	Modularity float64
+	// LayoutModularity is the modularity of the current layout of the files, comparable with
+	// Modularity.
+	LayoutModularity float64
+	// Agreement is the ratio of files that are in the same cluster as most of the files in
+	// their group.
+	Agreement float64
+	// Misplaced are the files that might be better in a different group.
+	Misplaced []Misplaced
+}
+
+// Analyze finds the clusters in the graph and compares them with the groups of files
+// determined by groupBy.
+func Analyze(g *graph.Graph[*language.FileInfo], groupBy language.GroupBy, resolution float64) Report {
+	report := Report{Clusters: g.Communities(resolution)}
+	report.Modularity = g.Modularity(report.Clusters, resolution)
+
+	// Files in each group, keeping the order in which groups are found.
+	var groupNames []string
+	groups := map[string][]*graph.Node[*language.FileInfo]{}
+	groupOf := map[string]string{}
+	for _, node := range g.AllNodes() {
+		group := groupBy(node.Data)
+		if _, ok := groups[group]; !ok {
+			groupNames = append(groupNames, group)
+		}
+		groups[group] = append(groups[group], node)
+		groupOf[node.Id] = group
+	}
+	layout := make([][]*graph.Node[*language.FileInfo], len(groupNames))
+	for i, name := range groupNames {
+		layout[i] = groups[name]
+	}
+	report.LayoutModularity = g.Modularity(layout, resolution)
+
+	clusterOf := map[string]int{}
+	for i, c := range report.Clusters {
+		for _, node := range c {
+			clusterOf[node.Id] = i
+		}
+	}
+	// The cluster where most of the files of each group are, and the group where most of the
+	// files of each cluster are. Ties are resolved in favor of the first one found.
+	groupCluster := map[string]int{}
+	agreeing := 0
+	for _, name := range groupNames {
+		clusters := make([]int, len(groups[name]))
+		for i, node := range groups[name] {
+			clusters[i] = clusterOf[node.Id]
+		}
+		cluster, count := majority(clusters)
+		groupCluster[name] = cluster
+		agreeing += count
+	}
+	clusterGroup := make([]string, len(report.Clusters))
+	for i, c := range report.Clusters {
+		names := make([]string, len(c))
+		for j, node := range c {
+			names[j] = groupOf[node.Id]
+		}
+		clusterGroup[i], _ = majority(names)
+	}
+	if n := len(groupOf); n > 0 {
+		report.Agreement = float64(agreeing) / float64(n)
+	}
+
+	for _, node := range g.AllNodes() {
+		group, cluster := groupOf[node.Id], clusterOf[node.Id]
+		if groupCluster[group] == cluster || clusterGroup[cluster] == group {
+			continue
+		}
+		report.Misplaced = append(report.Misplaced, Misplaced{
+			File:      node,
+			Group:     group,
+			Suggested: clusterGroup[cluster],
+		})
+	}
+	slices.SortStableFunc(report.Misplaced, func(a, b Misplaced) int {
+		return strings.Compare(a.File.Data.RelPath, b.File.Data.RelPath)
+	})
+	return report
+}
+
+// majority returns the most repeated value, and how many times it is repeated.
+func majority[K comparable](values []K) (K, int) {
+	counts := map[K]int{}
+	var best K
+	bestCount := 0
+	for _, v := range values {
+		counts[v]++
+		if counts[v] > bestCount {
+			best, bestCount = v, counts[v]
+		}
+	}
+	return best, bestCount
+}
@@ -0,0 +1,55 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+func TestAnalyze(t *testing.T) {
+	a := require.New(t)
+	files := []string{
+		"users/api.py", "users/model.py", "users/db.py",
+		"orders/api.py", "orders/model.py", "orders/db.py", "orders/user_format.py",
+	}
+	g := graph.NewGraph[*language.FileInfo]()
+	for _, file := range files {
+		g.AddNode(graph.MakeNode(file, &language.FileInfo{RelPath: file}))
+	}
+	for from, tos := range map[string][]string{
+		"users/api.py":    {"users/model.py", "users/db.py", "orders/user_format.py"},
+		"users/model.py":  {"orders/user_format.py"},
+		"users/db.py":     {"users/model.py", "orders/user_format.py"},
+		"orders/api.py":   {"orders/model.py", "orders/db.py", "users/api.py"},
+		"orders/db.py":    {"orders/model.py"},
+		"orders/model.py": {},
+	} {
+		a.NoError(g.AddFromToEdge(from, tos...))
+	}
+	groupBy, err := language.ParseGroupBy("dir")
+	a.NoError(err)
+
+	report := Analyze(g, groupBy, 1)
+
+	var clusters [][]string
+	for _, c := range report.Clusters {
+		var ids []string
+		for _, node := range c {
+			ids = append(ids, node.Id)
+		}
+		clusters = append(clusters, ids)
+	}
+	a.Equal([][]string{
+		{"users/api.py", "users/model.py", "users/db.py", "orders/user_format.py"},
+		{"orders/api.py", "orders/model.py", "orders/db.py"},
+	}, clusters)
+	a.Greater(report.Modularity, report.LayoutModularity)
+	a.InDelta(6.0/7, report.Agreement, 1e-9)
+	a.Len(report.Misplaced, 1)
+	a.Equal("orders/user_format.py", report.Misplaced[0].File.Id)
+	a.Equal("orders", report.Misplaced[0].Group)
+	a.Equal("users", report.Misplaced[0].Suggested)
+}
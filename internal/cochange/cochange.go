@@ -0,0 +1,137 @@
+package cochange
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+// Options tune which pairs of files are reported.
+type Options struct {
+	// MinCoChanges is the amount of commits in which two files must have changed together, or
+	// that two dependent files must have changed separately, for the pair to be reported.
+	MinCoChanges int
+	// MinCoupling is the minimum Coupling, from 0 to 1, of the reported pairs of files.
+	MinCoupling float64
+	// MaxCommitSize ignores the commits that change more files than this, like bulk renames or
+	// formatting changes, which do not reflect any coupling. 0 means no limit.
+	MaxCommitSize int
+}
+
+// Pair is a pair of files and how often they changed together.
+type Pair struct {
+	A string `json:"a"`
+	B string `json:"b"`
+	// ChangesA and ChangesB are the amount of commits in which each file changed.
+	ChangesA int `json:"changesA"`
+	ChangesB int `json:"changesB"`
+	// CoChanges is the amount of commits in which both files changed.
+	CoChanges int `json:"coChanges"`
+	// Coupling is CoChanges divided by the average amount of changes of both files, from 0 to 1.
+	Coupling float64 `json:"coupling"`
+	// Dependent tells whether one of the files depends on the other one.
+	Dependent bool `json:"dependent"`
+}
+
+// Report compares how files changed together with how they depend on each other.
+type Report struct {
+	// Commits is the amount of commits that were taken into account.
+	Commits int `json:"commits"`
+	// Pairs are all the files that changed together often enough, ranked by coupling.
+	Pairs []Pair `json:"pairs"`
+	// Hidden are the pairs that change together but do not depend on each other, which
+	// usually means that they are coupled in a way that is not visible in the code.
+	Hidden []Pair `json:"hidden"`
+	// NeverTogether are dependencies, from A to B, whose files changed often but never in the
+	// same commit, which might mean that the dependency can be removed.
+	NeverTogether []Pair `json:"neverTogether"`
+}
+
+// Analyze counts how often the files in g changed together in changeSets, which are the ids of
+// the files changed by each commit, and compares it with the dependencies between them.
+func Analyze[T any](g *graph.Graph[T], changeSets [][]string, opts Options) Report {
+	report := Report{Pairs: make([]Pair, 0), Hidden: make([]Pair, 0), NeverTogether: make([]Pair, 0)}
+	changes := map[string]int{}
+	coChanges := map[[2]string]int{}
+	for _, changeSet := range changeSets {
+		if opts.MaxCommitSize > 0 && len(changeSet) > opts.MaxCommitSize {
+			continue
+		}
+		report.Commits++
+		var ids []string
+		for _, id := range changeSet {
+			if g.Has(id) {
+				ids = append(ids, id)
+			}
+		}
+		slices.Sort(ids)
+		ids = slices.Compact(ids)
+		for i, a := range ids {
+			changes[a]++
+			for _, b := range ids[i+1:] {
+				coChanges[[2]string{a, b}]++
+			}
+		}
+	}
+
+	for key, count := range coChanges {
+		pair := makePair(key[0], key[1], changes, count)
+		if count < opts.MinCoChanges || pair.Coupling < opts.MinCoupling {
+			continue
+		}
+		pair.Dependent = g.HasEdgeBetween(g.Get(key[0]).ID(), g.Get(key[1]).ID())
+		report.Pairs = append(report.Pairs, pair)
+	}
+	slices.SortFunc(report.Pairs, comparePairs)
+	for _, pair := range report.Pairs {
+		if !pair.Dependent {
+			report.Hidden = append(report.Hidden, pair)
+		}
+	}
+
+	for _, node := range g.AllNodes() {
+		for _, dep := range g.FromId(node.Id) {
+			a, b := node.Id, dep.Id
+			key := [2]string{min(a, b), max(a, b)}
+			if coChanges[key] > 0 || min(changes[a], changes[b]) < max(opts.MinCoChanges, 1) {
+				continue
+			}
+			pair := makePair(a, b, changes, 0)
+			pair.Dependent = true
+			report.NeverTogether = append(report.NeverTogether, pair)
+		}
+	}
+	// The files that changed the most without ever changing together come first.
+	slices.SortFunc(report.NeverTogether, func(x, y Pair) int {
+		if c := cmp.Compare(min(y.ChangesA, y.ChangesB), min(x.ChangesA, x.ChangesB)); c != 0 {
+			return c
+		}
+		return comparePairs(x, y)
+	})
+	return report
+}
+
+func makePair(a, b string, changes map[string]int, coChanges int) Pair {
+	return Pair{
+		A:         a,
+		B:         b,
+		ChangesA:  changes[a],
+		ChangesB:  changes[b],
+		CoChanges: coChanges,
+		Coupling:  2 * float64(coChanges) / float64(changes[a]+changes[b]),
+	}
+}
+
+func comparePairs(x, y Pair) int {
+	if c := cmp.Compare(y.Coupling, x.Coupling); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(y.CoChanges, x.CoChanges); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(x.A, y.A); c != 0 {
+		return c
+	}
+	return cmp.Compare(x.B, y.B)
+}
@@ -0,0 +1,44 @@
+package cochange
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+func TestAnalyze(t *testing.T) {
+	a := require.New(t)
+	g := graph.NewGraph[string]()
+	for _, id := range []string{"api.py", "model.py", "schema.sql", "db.py", "utils.py"} {
+		g.AddNode(graph.MakeNode(id, id))
+	}
+	a.NoError(g.AddFromToEdge("api.py", "model.py", "utils.py"))
+	a.NoError(g.AddFromToEdge("model.py", "db.py"))
+
+	changeSets := [][]string{
+		{"api.py", "model.py"},
+		{"api.py", "model.py", "README.md"},
+		{"db.py", "schema.sql"},
+		{"db.py", "schema.sql"},
+		{"db.py", "schema.sql", "api.py"},
+		{"utils.py"},
+		{"utils.py"},
+		// Too big, so it does not count.
+		{"api.py", "model.py", "schema.sql", "db.py", "utils.py"},
+	}
+
+	report := Analyze(g, changeSets, Options{MinCoChanges: 2, MinCoupling: 0.5, MaxCommitSize: 4})
+
+	a.Equal(7, report.Commits)
+	a.Equal([]Pair{
+		{A: "db.py", B: "schema.sql", ChangesA: 3, ChangesB: 3, CoChanges: 3, Coupling: 1},
+		{A: "api.py", B: "model.py", ChangesA: 3, ChangesB: 2, CoChanges: 2, Coupling: 0.8, Dependent: true},
+	}, report.Pairs)
+	a.Equal(report.Pairs[:1], report.Hidden)
+	a.Equal([]Pair{
+		{A: "api.py", B: "utils.py", ChangesA: 3, ChangesB: 2, Dependent: true},
+		{A: "model.py", B: "db.py", ChangesA: 2, ChangesB: 3, Dependent: true},
+	}, report.NeverTogether)
+}
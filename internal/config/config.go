@@ -2,7 +2,10 @@ package config
 
 import (
 	"bytes"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -24,17 +27,21 @@ const DefaultConfigPath = ".dep-tree.yml"
 var SampleConfig string
 
 type Config struct {
-	Path          string
-	Source        string
-	Exclude       []string      `yaml:"exclude"`
-	Only          []string      `yaml:"only"`
-	UnwrapExports bool          `yaml:"unwrapExports"`
-	Check         check.Config  `yaml:"check"`
-	Js            js.Config     `yaml:"js"`
-	Rust          rust.Config   `yaml:"rust"`
-	Python        python.Config `yaml:"python"`
-	Golang        golang.Config `yaml:"golang"`
-	Cpp           cpp.Config    `yaml:"cpp"`
+	Path            string
+	Source          string
+	Jobs            int           `yaml:"-"`
+	GroupBy         string        `yaml:"-"`
+	Exclude         []string      `yaml:"exclude"`
+	Only            []string      `yaml:"only"`
+	CacheDir        string        `yaml:"cacheDir"`
+	UnwrapExports   bool          `yaml:"unwrapExports"`
+	IncludeExternal bool          `yaml:"includeExternal"`
+	Check           check.Config  `yaml:"check"`
+	Js              js.Config     `yaml:"js"`
+	Rust            rust.Config   `yaml:"rust"`
+	Python          python.Config `yaml:"python"`
+	Golang          golang.Config `yaml:"golang"`
+	Cpp             cpp.Config    `yaml:"cpp"`
 }
 
 func NewConfigCwd() Config {
@@ -54,6 +61,18 @@ func (c *Config) EnsureAbsPaths() {
 			c.Only[i] = filepath.Join(c.Path, file)
 		}
 	}
+
+	if c.CacheDir != "" && !filepath.IsAbs(c.CacheDir) {
+		c.CacheDir = filepath.Join(c.Path, c.CacheDir)
+	}
+}
+
+// LanguageFingerprint identifies the language settings, which are the ones that
+// affect how files are parsed.
+func (c *Config) LanguageFingerprint() string {
+	content, _ := json.Marshal([]any{c.Js, c.Rust, c.Python, c.Golang, c.Cpp})
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:])
 }
 
 func (c *Config) ValidatePatterns() error {
@@ -0,0 +1,3 @@
+#include "./first.h"
+#include "./broken.h
+#include "./last.h"
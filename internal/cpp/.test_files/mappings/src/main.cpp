@@ -0,0 +1,3 @@
+#include "proto/message.pb.h"
+#include "config.h"
+#include "./utils.h"
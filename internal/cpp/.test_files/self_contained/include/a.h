@@ -0,0 +1,5 @@
+#pragma once
+#include <vector>
+
+// std::map is only mentioned in a comment.
+inline std::vector<std::string> names() { return {"std::unique_ptr"}; }
@@ -0,0 +1,4 @@
+#pragma once
+#include <c.h>
+
+inline std::string name() { return "b"; }
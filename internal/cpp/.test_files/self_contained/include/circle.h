@@ -0,0 +1,4 @@
+#pragma once
+
+// Not every file including it includes types.h before, so the heuristic cannot tell.
+inline int diameter(Point center, int radius) { return 2 * radius; }
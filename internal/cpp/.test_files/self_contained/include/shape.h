@@ -0,0 +1,4 @@
+#pragma once
+
+// Relies on types.h being included before.
+inline int area(Point a, Point b) { return (b.x - a.x) * (b.y - a.y); }
@@ -0,0 +1,8 @@
+#pragma once
+
+struct Point {
+  int x;
+  int y;
+};
+
+using Points = Point*;
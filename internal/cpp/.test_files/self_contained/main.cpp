@@ -0,0 +1,8 @@
+#include <a.h>
+#include <b.h>
+#include <types.h>
+#include <shape.h>
+#include <circle.h>
+#include <widget.h>
+
+int main() { return 0; }
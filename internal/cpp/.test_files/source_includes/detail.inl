@@ -0,0 +1 @@
+#include "./utils.h"
@@ -0,0 +1 @@
+int impl() { return 0; }
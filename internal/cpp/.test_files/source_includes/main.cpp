@@ -0,0 +1,2 @@
+#include "./impl.cpp"
+#include "./detail.inl"
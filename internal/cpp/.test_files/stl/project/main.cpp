@@ -0,0 +1,4 @@
+#include <../system/extra.h>
+#include <vector>
+
+int main() { return 0; }
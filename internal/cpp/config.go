@@ -3,4 +3,61 @@ package cpp
 type Config struct {
 	RecursiveIncludePaths    []string `yaml:"recursiveIncludePaths"`
 	NonRecursiveIncludePaths []string `yaml:"nonRecursiveIncludePaths"`
+	// PathMappings map directories with generated or out-of-tree headers, like the
+	// build directory, to the virtual location in the sources where they should be
+	// displayed.
+	PathMappings []PathMapping `yaml:"pathMappings"`
+	// Generators fold generated files into the file they were generated from, for
+	// example, `*.pb.h` headers into their `.proto` file.
+	Generators []GeneratorRule `yaml:"generators"`
+	// ForbidSourceIncludes makes `check` fail if a source file, like impl.cpp, is
+	// included as if it was a header.
+	ForbidSourceIncludes bool `yaml:"forbidSourceIncludes"`
+	// SelfContained configures the `check` verification that project headers can be
+	// compiled on their own.
+	SelfContained SelfContainedConfig `yaml:"selfContained"`
+}
+
+// PathMapping maps the real directory From to the virtual directory To.
+//
+//	from: build/src
+//	to:   src
+//
+// will display build/src/foo.pb.h as src/foo.pb.h.
+type PathMapping struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// GeneratorRule tells that files matching the Generated pattern come from the
+// Source pattern. Both patterns are matched against the file name, and a single
+// `*` captures the part of the name that is shared between them:
+//
+//	generated: "*.pb.h"
+//	source:    "*.proto"
+type GeneratorRule struct {
+	Generated string `yaml:"generated"`
+	Source    string `yaml:"source"`
+}
+
+const (
+	// SelfContainedHeuristic looks for standard library symbols used by a header without
+	// directly including the standard header that declares them, and for identifiers
+	// declared in a project header that every includer includes before it, but that
+	// it does not include itself.
+	SelfContainedHeuristic = "heuristic"
+	// SelfContainedCompiler compiles each header on its own with -fsyntax-only.
+	SelfContainedCompiler = "compiler"
+)
+
+type SelfContainedConfig struct {
+	// Mode is either "heuristic" or "compiler". The check is disabled if it's empty.
+	Mode string `yaml:"mode"`
+	// Compiler is the compiler invoked in "compiler" mode, c++ by default.
+	Compiler string `yaml:"compiler"`
+	// CompileCommands is the path to a compile_commands.json file. Each header is
+	// compiled with the flags of a translation unit that includes it.
+	CompileCommands string `yaml:"compileCommands"`
+	// Flags are added to every compiler invocation.
+	Flags []string `yaml:"flags"`
 }
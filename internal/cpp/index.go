@@ -0,0 +1,66 @@
+package cpp
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// pathSet is a set of paths that is safe for concurrent use. The zero value is an empty set.
+type pathSet struct {
+	mu    sync.RWMutex
+	paths map[string]struct{}
+}
+
+func (s *pathSet) Add(path string) {
+	s.mu.Lock()
+	if s.paths == nil {
+		s.paths = make(map[string]struct{})
+	}
+	s.paths[path] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *pathSet) Has(path string) bool {
+	s.mu.RLock()
+	_, ok := s.paths[path]
+	s.mu.RUnlock()
+	return ok
+}
+
+// dirIndex caches directory listings, so that checking whether an included file
+// exists under an include root hits the filesystem at most once per directory.
+// It is safe for concurrent use, and the zero value is ready to be used.
+type dirIndex struct {
+	// dirs maps a directory to its entries, where each entry tells whether it's a directory.
+	dirs sync.Map
+}
+
+func (d *dirIndex) entries(dir string) map[string]bool {
+	if cached, ok := d.dirs.Load(dir); ok {
+		return cached.(map[string]bool)
+	}
+	listing, err := os.ReadDir(dir)
+	entries := make(map[string]bool, len(listing))
+	if err == nil {
+		for _, entry := range listing {
+			isDir := entry.IsDir()
+			// Symlinks need to be followed to know what they point to.
+			if entry.Type()&os.ModeSymlink != 0 {
+				if stat, err := os.Stat(filepath.Join(dir, entry.Name())); err == nil {
+					isDir = stat.IsDir()
+				}
+			}
+			entries[entry.Name()] = isDir
+		}
+	}
+	actual, _ := d.dirs.LoadOrStore(dir, entries)
+	return actual.(map[string]bool)
+}
+
+// FileExists tells whether path is an existing file, not a directory.
+func (d *dirIndex) FileExists(path string) bool {
+	path = filepath.Clean(path)
+	isDir, ok := d.entries(filepath.Dir(path))[filepath.Base(path)]
+	return ok && !isDir
+}
@@ -2,22 +2,51 @@ package cpp
 
 import (
 	"bytes"
-	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
 
+	"github.com/gabotechs/dep-tree/internal/graph"
 	"github.com/gabotechs/dep-tree/internal/language"
 )
 
+// Language is safe for concurrent use, the state shared across files is kept in
+// structures that can be accessed from multiple goroutines.
 type Language struct {
-	Cfg                 *Config
-	AllowedSTLFilepaths []string
+	Cfg *Config
+	// allowedSTLFilepaths are the files in non-recursive include paths that were included
+	// from files in recursive include paths.
+	allowedSTLFilepaths pathSet
+	// files caches the directory listings used for resolving includes.
+	files dirIndex
+	// pathMappings are the Cfg.PathMappings with absolute paths.
+	pathMappings []PathMapping
 }
 
 func MakeCppLanguage(cfg *Config) (language.Language, error) {
-	return &Language{Cfg: cfg}, nil
+	lang := &Language{Cfg: cfg}
+	if cfg != nil {
+		for _, mapping := range cfg.PathMappings {
+			from, err := filepath.Abs(mapping.From)
+			if err != nil {
+				return nil, err
+			}
+			to, err := filepath.Abs(mapping.To)
+			if err != nil {
+				return nil, err
+			}
+			lang.pathMappings = append(lang.pathMappings, PathMapping{From: from, To: to})
+		}
+	}
+	return lang, nil
+}
+
+// IsStateful is true if there are non-recursive include paths, as whether their files are
+// parsed depends on allowedSTLFilepaths, which is filled while parsing other files.
+func (l *Language) IsStateful() bool {
+	return l.Cfg != nil && len(l.Cfg.NonRecursiveIncludePaths) > 0
 }
 
 func (l *Language) GetIncludePath(path string) (includePath string, recursive bool, err error) {
@@ -32,12 +61,19 @@ func (l *Language) GetIncludePath(path string) (includePath string, recursive bo
 			// If file is in stl
 			if strings.HasPrefix(path, includePath) {
 				// If file hasn't been included from a non-stl filepath, then skip the file
-				if !slices.Contains(l.AllowedSTLFilepaths, path) {
+				if !l.allowedSTLFilepaths.Has(path) {
 					return includePath, false, nil
 				}
 				break
 			}
 		}
+
+		// Directories with generated headers are part of the project.
+		for _, mapping := range l.pathMappings {
+			if isInDir(path, mapping.From) {
+				return mapping.From, true, nil
+			}
+		}
 	}
 
 	return "", false, os.ErrNotExist
@@ -51,7 +87,7 @@ func (l *Language) GetABSPath(includedPath string) (found bool, absPath string,
 	for _, includePath := range l.Cfg.RecursiveIncludePaths {
 		var innerAbsPath = filepath.Join(includePath, includedPath)
 
-		if _, err := os.Stat(innerAbsPath); errors.Is(err, os.ErrNotExist) {
+		if !l.files.FileExists(innerAbsPath) {
 			continue
 		}
 
@@ -66,7 +102,7 @@ func (l *Language) GetABSPath(includedPath string) (found bool, absPath string,
 		for _, includePath := range l.Cfg.NonRecursiveIncludePaths {
 			var innerAbsPath = filepath.Join(includePath, includedPath)
 
-			if _, err := os.Stat(innerAbsPath); errors.Is(err, os.ErrNotExist) {
+			if !l.files.FileExists(innerAbsPath) {
 				continue
 			}
 
@@ -83,7 +119,7 @@ func (l *Language) GetABSPath(includedPath string) (found bool, absPath string,
 
 func (l *Language) ParseFile(path string) (*language.FileInfo, error) {
 	currentDir, _ := os.Getwd()
-	relPath, _ := filepath.Rel(currentDir, path)
+	relPath, _ := filepath.Rel(currentDir, l.VirtualPath(path))
 
 	// If the file has an extension, and that extension is non-c++
 	ext := filepath.Ext(path)
@@ -121,7 +157,7 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 	if err != nil {
 		return &result, nil
 		// If the file is from the STL and isn't on the exception list, skip it
-	} else if !isRecursive && !slices.Contains(l.AllowedSTLFilepaths, file.AbsPath) {
+	} else if !isRecursive && !l.allowedSTLFilepaths.Has(file.AbsPath) {
 		return &result, nil
 	}
 
@@ -133,6 +169,9 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 			includePath = statement.Quoted.IncludedFile
 		} else if statement.Angled != nil {
 			includePath = statement.Angled.IncludedFile
+		} else if statement.Invalid != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("malformed include at line %d: %s", statement.Invalid.Pos.Line, statement.Invalid.Line))
+			continue
 		} else {
 			continue
 		}
@@ -140,9 +179,19 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 		// If it's a relative include, add the filepaths together and add it as an Import
 		if strings.HasPrefix(includePath, ".") {
 			var absInclude = filepath.Clean(filepath.Join(filepath.Dir(file.AbsPath), includePath))
+			if !l.files.FileExists(absInclude) {
+				if mapped, ok := l.resolveMapped(filepath.Dir(file.AbsPath), includePath); ok {
+					absInclude = mapped
+				}
+			}
+			absInclude = l.fold(absInclude)
+			if isSourceFile(absInclude) {
+				result.Errors = append(result.Errors, fmt.Errorf("source file %s is included as if it was a header", includePath))
+			}
 			result.Imports = append(result.Imports, language.ImportEntry{
 				Symbols: []string{absInclude},
 				AbsPath: absInclude,
+				Line:    statement.Pos.Line,
 			})
 			continue
 		}
@@ -151,16 +200,25 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 		found, absPath, isRecursive := l.GetABSPath(includePath)
 
 		if !found {
-			continue
+			// The include might point to a generated header outside the sources.
+			if absPath, found = l.resolveMapped(filepath.Dir(file.AbsPath), includePath); !found {
+				continue
+			}
+			isRecursive = true
+		}
+		absPath = l.fold(absPath)
+		if isSourceFile(absPath) {
+			result.Errors = append(result.Errors, fmt.Errorf("source file %s is included as if it was a header", includePath))
 		}
 
 		if isRecursive {
-			l.AllowedSTLFilepaths = append(l.AllowedSTLFilepaths, absPath)
+			l.allowedSTLFilepaths.Add(absPath)
 		}
 
 		result.Imports = append(result.Imports, language.ImportEntry{
 			Symbols: []string{absPath},
 			AbsPath: absPath,
+			Line:    statement.Pos.Line,
 		})
 
 		/*
@@ -237,5 +295,38 @@ func (l *Language) ParseExports(file *language.FileInfo) (*language.ExportsResul
 	return &result, nil
 }
 
-var Extensions = []string{"h", "hpp", "hh", // Header extensions
-	"cpp", "cxx", "C", "cc", "c++", "cppm", "ixx"} // Source extensions
+// HeaderExtensions are the extensions of files meant to be included. Inline and template
+// implementation files are included the same way as headers.
+var HeaderExtensions = []string{"h", "hpp", "hh", "inl", "ipp", "tcc", "tpp"}
+
+// SourceExtensions are the extensions of translation units, which should never be included.
+var SourceExtensions = []string{"cpp", "cxx", "C", "cc", "c++", "cppm", "ixx"}
+
+var Extensions = append(slices.Clone(HeaderExtensions), SourceExtensions...)
+
+func isSourceFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext != "" && slices.Contains(SourceExtensions, ext[1:])
+}
+
+// CheckSourceIncludes reports the source files in g that are included by other files, if
+// ForbidSourceIncludes is enabled. The returned violations are ready to be displayed.
+func (l *Language) CheckSourceIncludes(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+	if l.Cfg == nil || !l.Cfg.ForbidSourceIncludes {
+		return nil, nil
+	}
+	var violations []string
+	for _, node := range g.AllNodes() {
+		for _, dep := range g.FromId(node.Id) {
+			if !isSourceFile(dep.Id) {
+				continue
+			}
+			from := node.Data.RelPath
+			if data, ok := g.EdgeData(node.Id, dep.Id).(graph.LineData); ok && data.Line() > 0 {
+				from += fmt.Sprintf(":%d", data.Line())
+			}
+			violations = append(violations, fmt.Sprintf("%s -> %s", from, dep.Data.RelPath))
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,299 @@
+package cpp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+func TestLanguage_PathMappings(t *testing.T) {
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, ".test_files", "mappings")
+	src := filepath.Join(root, "src")
+	build := filepath.Join(root, "build", "src")
+
+	tests := []struct {
+		Name       string
+		Generators []GeneratorRule
+		Expected   []string
+	}{
+		{
+			Name: "without generators",
+			Expected: []string{
+				filepath.Join(build, "proto", "message.pb.h"),
+				filepath.Join(build, "config.h"),
+				filepath.Join(src, "utils.h"),
+			},
+		},
+		{
+			Name:       "generated files are folded into their source",
+			Generators: []GeneratorRule{{Generated: "*.pb.h", Source: "*.proto"}},
+			Expected: []string{
+				filepath.Join(src, "proto", "message.proto"),
+				filepath.Join(build, "config.h"),
+				filepath.Join(src, "utils.h"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			lang, err := MakeCppLanguage(&Config{
+				RecursiveIncludePaths: []string{src},
+				PathMappings:          []PathMapping{{From: build, To: src}},
+				Generators:            tt.Generators,
+			})
+			a.NoError(err)
+
+			file, err := lang.ParseFile(filepath.Join(src, "main.cpp"))
+			a.NoError(err)
+			result, err := lang.ParseImports(file)
+			a.NoError(err)
+
+			var imports []string
+			for _, imp := range result.Imports {
+				imports = append(imports, imp.AbsPath)
+			}
+			a.Equal(tt.Expected, imports)
+		})
+	}
+}
+
+func TestLanguage_VirtualPath(t *testing.T) {
+	a := require.New(t)
+	cfg := &Config{
+		PathMappings: []PathMapping{{From: "/repo/build/src", To: "/repo/src"}},
+	}
+	lang := &Language{Cfg: cfg, pathMappings: cfg.PathMappings}
+
+	a.Equal("/repo/src/proto/message.pb.h", lang.VirtualPath("/repo/build/src/proto/message.pb.h"))
+	a.Equal("/repo/build/other.h", lang.VirtualPath("/repo/build/other.h"))
+	a.Equal("/repo/build/srcs/other.h", lang.VirtualPath("/repo/build/srcs/other.h"))
+}
+
+func TestLanguage_GeneratedRelPath(t *testing.T) {
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, ".test_files", "mappings")
+
+	cfg := &Config{
+		PathMappings: []PathMapping{{From: filepath.Join(".test_files", "mappings", "build", "src"), To: filepath.Join(".test_files", "mappings", "src")}},
+	}
+	lang, err := MakeCppLanguage(cfg)
+	a.NoError(err)
+	// The config is not modified, as it is part of the fingerprint of the cache.
+	a.Equal(filepath.Join(".test_files", "mappings", "build", "src"), cfg.PathMappings[0].From)
+
+	var file *language.FileInfo
+	file, err = lang.ParseFile(filepath.Join(root, "build", "src", "config.h"))
+	a.NoError(err)
+	a.Equal(filepath.Join(".test_files", "mappings", "src", "config.h"), file.RelPath)
+	a.Equal(filepath.Join(root, "build", "src", "config.h"), file.AbsPath)
+}
+
+func TestLanguage_SourceIncludes(t *testing.T) {
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, ".test_files", "source_includes")
+
+	lang, err := MakeCppLanguage(&Config{RecursiveIncludePaths: []string{root}})
+	a.NoError(err)
+
+	file, err := lang.ParseFile(filepath.Join(root, "main.cpp"))
+	a.NoError(err)
+	result, err := lang.ParseImports(file)
+	a.NoError(err)
+	a.Len(result.Imports, 2)
+	a.Len(result.Errors, 1)
+	a.ErrorContains(result.Errors[0], "source file ./impl.cpp is included as if it was a header")
+
+	inl, err := lang.ParseFile(filepath.Join(root, "detail.inl"))
+	a.NoError(err)
+	result, err = lang.ParseImports(inl)
+	a.NoError(err)
+	a.Len(result.Imports, 1)
+	a.Equal(filepath.Join(root, "utils.h"), result.Imports[0].AbsPath)
+	a.Empty(result.Errors)
+}
+
+func TestLanguage_CheckSourceIncludes(t *testing.T) {
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, ".test_files", "source_includes")
+
+	for _, forbid := range []bool{false, true} {
+		lang, err := MakeCppLanguage(&Config{RecursiveIncludePaths: []string{root}, ForbidSourceIncludes: forbid})
+		a.NoError(err)
+		g := graph.NewGraph[*language.FileInfo]()
+		a.NoError(g.Load([]string{filepath.Join(root, "main.cpp")}, language.NewParser(lang), nil))
+
+		violations, err := lang.(*Language).CheckSourceIncludes(g)
+		a.NoError(err)
+		if forbid {
+			rel := filepath.Join(".test_files", "source_includes")
+			a.Equal([]string{filepath.Join(rel, "main.cpp") + ":1 -> " + filepath.Join(rel, "impl.cpp")}, violations)
+		} else {
+			a.Empty(violations)
+		}
+	}
+}
+
+func TestLanguage_DiskCache(t *testing.T) {
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, ".test_files", "stl")
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+
+	// edges loads the graph with a fresh Language, as a new run would do.
+	edges := func() []string {
+		lang, err := MakeCppLanguage(&Config{
+			RecursiveIncludePaths:    []string{filepath.Join(root, "project")},
+			NonRecursiveIncludePaths: []string{filepath.Join(root, "system")},
+		})
+		a.NoError(err)
+		g := graph.NewGraph[*language.FileInfo]()
+		parser := language.NewParser(language.NewDiskCache(lang, cacheDir, ""))
+		a.NoError(g.Load([]string{filepath.Join(root, "project", "main.cpp")}, parser, nil))
+		var result []string
+		for _, node := range g.AllNodes() {
+			for _, dep := range g.FromId(node.Id) {
+				result = append(result, node.Data.RelPath+" -> "+dep.Data.RelPath)
+			}
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	cold := edges()
+	a.NotEmpty(cold)
+	a.Equal(cold, edges())
+	// Parsing depends on the files included before, so nothing is cached.
+	a.NoDirExists(cacheDir)
+}
+
+// makeIncludeTree writes a project with `modules` directories of `headers` headers each
+// to a temporary include root. Every header includes a few headers from other modules.
+func makeIncludeTree(tb testing.TB, modules int, headers int) (string, []string) {
+	root := tb.TempDir()
+	var files []string
+	for m := 0; m < modules; m++ {
+		dir := filepath.Join(root, fmt.Sprintf("mod%d", m))
+		if err := os.Mkdir(dir, os.ModePerm); err != nil {
+			tb.Fatal(err)
+		}
+		for h := 0; h < headers; h++ {
+			var content strings.Builder
+			for k := 1; k <= 3; k++ {
+				content.WriteString(fmt.Sprintf("#include <mod%d/h%d.h>\n", (m+k)%modules, (h+k)%headers))
+			}
+			content.WriteString("#include \"./missing.h\"\n")
+			file := filepath.Join(dir, fmt.Sprintf("h%d.h", h))
+			if err := os.WriteFile(file, []byte(content.String()), 0o600); err != nil {
+				tb.Fatal(err)
+			}
+			files = append(files, file)
+		}
+	}
+	return root, files
+}
+
+func parseAllImports(lang language.Language, file string) ([]string, error) {
+	parsed, err := lang.ParseFile(file)
+	if err != nil {
+		return nil, err
+	}
+	result, err := lang.ParseImports(parsed)
+	if err != nil {
+		return nil, err
+	}
+	imports := make([]string, len(result.Imports))
+	for i, imp := range result.Imports {
+		imports[i] = imp.AbsPath
+	}
+	return imports, nil
+}
+
+func TestLanguage_ConcurrentParsing(t *testing.T) {
+	a := require.New(t)
+	root, files := makeIncludeTree(t, 10, 10)
+
+	sequential, err := MakeCppLanguage(&Config{RecursiveIncludePaths: []string{root}})
+	a.NoError(err)
+	expected := make([][]string, len(files))
+	for i, file := range files {
+		expected[i], err = parseAllImports(sequential, file)
+		a.NoError(err)
+	}
+
+	concurrent, err := MakeCppLanguage(&Config{RecursiveIncludePaths: []string{root}})
+	a.NoError(err)
+	actual := make([][]string, len(files))
+	errs := make([]error, len(files))
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file string) {
+			defer wg.Done()
+			actual[i], errs[i] = parseAllImports(concurrent, file)
+		}(i, file)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		a.NoError(err)
+	}
+	a.Equal(expected, actual)
+}
+
+func BenchmarkLanguage_ParseImports(b *testing.B) {
+	for _, size := range []int{10, 100} {
+		root, files := makeIncludeTree(b, size, size)
+		b.Run(fmt.Sprintf("%d files", len(files)), func(b *testing.B) {
+			lang, err := MakeCppLanguage(&Config{RecursiveIncludePaths: []string{root}})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if _, err := parseAllImports(lang, files[i%len(files)]); err != nil {
+						b.Error(err)
+					}
+					i++
+				}
+			})
+		})
+	}
+}
+
+func TestLanguage_MalformedIncludes(t *testing.T) {
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, ".test_files", "malformed")
+
+	lang, err := MakeCppLanguage(&Config{RecursiveIncludePaths: []string{root}})
+	a.NoError(err)
+
+	file, err := lang.ParseFile(filepath.Join(root, "main.cpp"))
+	a.NoError(err)
+	result, err := lang.ParseImports(file)
+	a.NoError(err)
+
+	a.Equal([]language.ImportEntry{
+		{Symbols: []string{filepath.Join(root, "first.h")}, AbsPath: filepath.Join(root, "first.h"), Line: 1},
+		{Symbols: []string{filepath.Join(root, "last.h")}, AbsPath: filepath.Join(root, "last.h"), Line: 3},
+	}, result.Imports)
+	a.Len(result.Errors, 1)
+	a.EqualError(result.Errors[0], `malformed include at line 2: #include "./broken.h`)
+}
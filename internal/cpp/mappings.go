@@ -0,0 +1,90 @@
+package cpp
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+func isInDir(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// VirtualPath returns the path where a file should be displayed. Files that live
+// inside one of the path mappings are relocated to the mapped virtual directory.
+func (l *Language) VirtualPath(path string) string {
+	for _, mapping := range l.pathMappings {
+		if isInDir(path, mapping.From) {
+			rel, _ := filepath.Rel(mapping.From, path)
+			return filepath.Join(mapping.To, rel)
+		}
+	}
+	return path
+}
+
+// resolveMapped looks for an included file in the directories with generated headers.
+// The included path is tried both as relative to the root of each mapped directory,
+// and as relative to the includer's directory translated into the mapped directory.
+func (l *Language) resolveMapped(includerDir string, includedPath string) (string, bool) {
+	if len(l.pathMappings) == 0 {
+		return "", false
+	}
+	virtualDir := l.VirtualPath(includerDir)
+	for _, mapping := range l.pathMappings {
+		candidates := []string{filepath.Join(mapping.From, includedPath)}
+		if isInDir(virtualDir, mapping.To) {
+			rel, _ := filepath.Rel(mapping.To, virtualDir)
+			candidates = append(candidates, filepath.Join(mapping.From, rel, includedPath))
+		}
+		for _, candidate := range candidates {
+			if l.files.FileExists(candidate) {
+				return filepath.Clean(candidate), true
+			}
+		}
+	}
+	return "", false
+}
+
+// matchGenerated returns the part of the name captured by the `*` of the pattern.
+func matchGenerated(pattern string, name string) (string, bool) {
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok {
+		return "", pattern == name
+	}
+	if len(name) < len(prefix)+len(suffix) || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return "", false
+	}
+	return name[len(prefix) : len(name)-len(suffix)], true
+}
+
+// GeneratorSource returns the file from which path was generated based on the configured
+// Generators. The source file is searched next to the generated file, both in its
+// real and in its virtual location.
+func (l *Language) GeneratorSource(path string) (string, bool) {
+	if l.Cfg == nil {
+		return "", false
+	}
+	name := filepath.Base(path)
+	for _, rule := range l.Cfg.Generators {
+		stem, ok := matchGenerated(rule.Generated, name)
+		if !ok {
+			continue
+		}
+		source := strings.Replace(rule.Source, "*", stem, 1)
+		for _, dir := range []string{filepath.Dir(l.VirtualPath(path)), filepath.Dir(path)} {
+			candidate := filepath.Join(dir, source)
+			if l.files.FileExists(candidate) {
+				return filepath.Clean(candidate), true
+			}
+		}
+	}
+	return "", false
+}
+
+// fold replaces generated files by the file they come from if it can be found.
+func (l *Language) fold(path string) string {
+	if source, ok := l.GeneratorSource(path); ok {
+		return source
+	}
+	return path
+}
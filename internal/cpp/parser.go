@@ -152,9 +152,18 @@ type AngledInclude struct {
 	IncludedFile string `@AngledInclude`
 }
 
+// InvalidInclude is an #include directive with an unterminated "path" or <path>. It is
+// kept so that the error can be reported without losing the rest of the includes in the file.
+type InvalidInclude struct {
+	Pos  lexer.Position
+	Line string `@InvalidInclude`
+}
+
 type Statement struct {
-	Quoted *QuotedInclude `@@`
-	Angled *AngledInclude `| @@`
+	Pos     lexer.Position
+	Quoted  *QuotedInclude  `@@`
+	Angled  *AngledInclude  `| @@`
+	Invalid *InvalidInclude `| @@`
 	// Empty   bool     `| (@Semi|"\n")` // Accept empty statements
 }
 
@@ -165,8 +174,11 @@ type File struct {
 var (
 	lex = lexer.MustSimple(
 		[]lexer.SimpleRule{
-			{"QuotedInclude", `#include\s+"[^"]+"`},
-			{"AngledInclude", `#include\s+<[^<]+>`},
+			{"QuotedInclude", `#[ \t]*include(_next)?[ \t]*"[^"\r\n]+"`},
+			{"AngledInclude", `#[ \t]*include(_next)?[ \t]*<[^<>\r\n]+>`},
+			// Computed includes, like #include HEADER_NAME, are valid but cannot be resolved
+			// without running the preprocessor, so they are not matched and end up ignored.
+			{"InvalidInclude", `#[ \t]*include(_next)?[ \t]*["<][^\r\n]*`},
 
 			// {"BadPreprocessor", "^#([^i]|i[^n]|in[^c]|inc[^l]|incl[^u]|inclu[^d]|includ[^e])"},
 			// {"Pragma", "#pragma.*\n"},
@@ -181,7 +193,7 @@ var (
 			// {"Newline", `[\n\r]+`},
 			// {"Ident", `([_a-zA-Z][a-zA-Z0-9]*::)*[_a-zA-Z0-9]+`},
 			{"LineComment", `//[^\r\n]*`},
-			{"BlockComment", `/\*(.|\n)+\*/`},
+			{"BlockComment", `/\*(.|\n)*?\*/`},
 			{"Whitespace", `\s+`},
 			{"Other", `.+`},
 
@@ -193,12 +205,13 @@ var (
 		// participle.Unquote("String", "Angled"),
 		participle.Elide("LineComment", "BlockComment", "Whitespace", "Other"),
 		participle.Map(func(token lexer.Token) (lexer.Token, error) {
-			token.Value = strings.Replace(token.Value, "#include", "", -1)
-			token.Value = strings.Replace(token.Value, `"`, "", -1)
-			token.Value = strings.Replace(token.Value, `<`, "", -1)
-			token.Value = strings.Replace(token.Value, `>`, "", -1)
-			token.Value = strings.Replace(token.Value, ` `, "", -1)
+			start := strings.IndexAny(token.Value, `"<`)
+			token.Value = strings.TrimSpace(token.Value[start+1 : len(token.Value)-1])
 			return token, nil
 		}, "QuotedInclude", "AngledInclude"),
+		participle.Map(func(token lexer.Token) (lexer.Token, error) {
+			token.Value = strings.TrimSpace(token.Value)
+			return token, nil
+		}, "InvalidInclude"),
 	)
 )
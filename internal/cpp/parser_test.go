@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/alecthomas/participle/v2/lexer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +19,7 @@ func TestParser(t *testing.T) {
 			Name:  "Quoted Include",
 			Input: `#include "file.h"`,
 			Statements: []Statement{{
+				Pos:    lexer.Position{Line: 1, Column: 1},
 				Quoted: &QuotedInclude{"file.h"},
 			}},
 		},
@@ -25,84 +27,35 @@ func TestParser(t *testing.T) {
 			Name:  "Angled Include",
 			Input: `#include <vector>`,
 			Statements: []Statement{{
+				Pos:    lexer.Position{Line: 1, Column: 1},
 				Angled: &AngledInclude{"vector"},
 			}},
 		},
 		{
-			Name: "Namespace with using and forward declarations",
-			Input: `
-namespace Foo {
-
-class ForwardedClass;
-using BarPtr = Ptr<Bar>;
-
-}  // namespace Presto`,
-			Statements: []Statement{
-				{
-					Dec: &Declaration{
-						Namespace: &NamespaceDef{
-							Name: "Foo",
-							Items: []Declaration{
-								{
-									Fwd: &FwdDec{
-										Class: &ClassFwd{
-											Name: "ForwardedClass",
-										},
-									},
-								},
-								{
-									Using: &UsingStatement{
-										Alias: &TypeAlias{
-											Identifier: "BarPtr",
-											TypeID:     "PtrBar",
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			Name:  "Spaces after the hash",
+			Input: `#  include   "file.h"`,
+			Statements: []Statement{{
+				Pos:    lexer.Position{Line: 1, Column: 1},
+				Quoted: &QuotedInclude{"file.h"},
+			}},
 		},
 		{
-			Name: "Namespace with function declarations",
-			Input: `
-			namespace testns {
-class ForwardedClass;
-
-ImagePtr LoadImage(const AssetArg& path, Presto::string name = "");
-
-}`,
-			Statements: []Statement{
-				{
-					Dec: &Declaration{
-						Namespace: &NamespaceDef{
-							Name: "testns",
-							Items: []Declaration{
-								{
-									Fwd: &FwdDec{
-										Class: &ClassFwd{
-											Name: "ForwardedClass",
-										},
-									},
-								},
-								{
-									Function: &FnDec{
-										LeadingReturnType: Type{Name: "ImagePtr"},
-										Name:              "LoadImage",
-										Parameters: []FunctionType{
-											{Type: Type{IsConst: true, Name: "AssetArg& path"},
-												Value: &Value{
-													String: &String{String: `""`}},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+			Name:  "Include next",
+			Input: `#include_next <stdlib.h>`,
+			Statements: []Statement{{
+				Pos:    lexer.Position{Line: 1, Column: 1},
+				Angled: &AngledInclude{"stdlib.h"},
+			}},
+		},
+		{
+			Name: "Includes between block comments",
+			Input: `/* first */
+#include "file.h"
+/* second */`,
+			Statements: []Statement{{
+				Pos:    lexer.Position{Offset: 12, Line: 2, Column: 1},
+				Quoted: &QuotedInclude{"file.h"},
+			}},
 		},
 	}
 
@@ -116,9 +69,11 @@ ImagePtr LoadImage(const AssetArg& path, Presto::string name = "");
 			File: "multi_includes.h",
 			Expected: []Statement{
 				{
+					Pos:    lexer.Position{Line: 1, Column: 1},
 					Quoted: &QuotedInclude{"file.h"},
 				},
 				{
+					Pos:    lexer.Position{Offset: 18, Line: 2, Column: 1},
 					Angled: &AngledInclude{"vector"},
 				}},
 		},
@@ -155,3 +110,23 @@ ImagePtr LoadImage(const AssetArg& path, Presto::string name = "");
 		})
 	}
 }
+
+func TestParser_InvalidInclude(t *testing.T) {
+	a := require.New(t)
+
+	result, err := parser.ParseString("", `#include "first.h"
+#include "unterminated.h
+#include MACRO_HEADER
+#include BOOST_PP_STRINGIZE(header.h)
+#include <unterminated.h
+#include <last.h>`)
+	a.NoError(err)
+
+	a.Len(result.Statements, 4)
+	a.Equal(&QuotedInclude{"first.h"}, result.Statements[0].Quoted)
+	a.Equal(`#include "unterminated.h`, result.Statements[1].Invalid.Line)
+	a.Equal(2, result.Statements[1].Invalid.Pos.Line)
+	a.Equal(`#include <unterminated.h`, result.Statements[2].Invalid.Line)
+	a.Equal(5, result.Statements[2].Invalid.Pos.Line)
+	a.Equal(&AngledInclude{"last.h"}, result.Statements[3].Angled)
+}
@@ -0,0 +1,479 @@
+package cpp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// stdSymbolHeaders maps commonly used standard library symbols to the standard headers
+// that are guaranteed to declare them.
+var stdSymbolHeaders = map[string][]string{
+	"vector":             {"vector"},
+	"string":             {"string"},
+	"wstring":            {"string"},
+	"to_string":          {"string"},
+	"string_view":        {"string_view"},
+	"map":                {"map"},
+	"multimap":           {"map"},
+	"set":                {"set"},
+	"multiset":           {"set"},
+	"unordered_map":      {"unordered_map"},
+	"unordered_multimap": {"unordered_map"},
+	"unordered_set":      {"unordered_set"},
+	"unordered_multiset": {"unordered_set"},
+	"deque":              {"deque"},
+	"list":               {"list"},
+	"forward_list":       {"forward_list"},
+	"array":              {"array"},
+	"queue":              {"queue"},
+	"priority_queue":     {"queue"},
+	"stack":              {"stack"},
+	"span":               {"span"},
+	"bitset":             {"bitset"},
+	"optional":           {"optional"},
+	"nullopt":            {"optional"},
+	"variant":            {"variant"},
+	"visit":              {"variant"},
+	"any":                {"any"},
+	"tuple":              {"tuple"},
+	"tie":                {"tuple"},
+	"make_tuple":         {"tuple"},
+	"pair":               {"utility", "map", "unordered_map"},
+	"make_pair":          {"utility", "map", "unordered_map"},
+	"move":               {"utility"},
+	"forward":            {"utility"},
+	"swap":               {"utility"},
+	"unique_ptr":         {"memory"},
+	"shared_ptr":         {"memory"},
+	"weak_ptr":           {"memory"},
+	"make_unique":        {"memory"},
+	"make_shared":        {"memory"},
+	"function":           {"functional"},
+	"hash":               {"functional", "string", "memory", "unordered_map", "unordered_set"},
+	"mutex":              {"mutex"},
+	"recursive_mutex":    {"mutex"},
+	"lock_guard":         {"mutex"},
+	"unique_lock":        {"mutex"},
+	"shared_mutex":       {"shared_mutex"},
+	"condition_variable": {"condition_variable"},
+	"thread":             {"thread"},
+	"atomic":             {"atomic"},
+	"future":             {"future"},
+	"promise":            {"future"},
+	"size_t":             {"cstddef", "cstdio", "cstdlib", "cstring", "ctime", "cwchar"},
+	"ptrdiff_t":          {"cstddef"},
+	"nullptr_t":          {"cstddef"},
+	"byte":               {"cstddef"},
+	"int8_t":             {"cstdint"},
+	"int16_t":            {"cstdint"},
+	"int32_t":            {"cstdint"},
+	"int64_t":            {"cstdint"},
+	"uint8_t":            {"cstdint"},
+	"uint16_t":           {"cstdint"},
+	"uint32_t":           {"cstdint"},
+	"uint64_t":           {"cstdint"},
+	"intptr_t":           {"cstdint"},
+	"uintptr_t":          {"cstdint"},
+	"ostream":            {"ostream", "iostream"},
+	"istream":            {"istream", "iostream"},
+	"iostream":           {"istream", "iostream"},
+	"cout":               {"iostream"},
+	"cerr":               {"iostream"},
+	"cin":                {"iostream"},
+	"endl":               {"ostream", "iostream"},
+	"stringstream":       {"sstream"},
+	"ostringstream":      {"sstream"},
+	"istringstream":      {"sstream"},
+	"ifstream":           {"fstream"},
+	"ofstream":           {"fstream"},
+	"fstream":            {"fstream"},
+	"sort":               {"algorithm"},
+	"find":               {"algorithm"},
+	"find_if":            {"algorithm"},
+	"min":                {"algorithm"},
+	"max":                {"algorithm"},
+	"accumulate":         {"numeric"},
+	"iota":               {"numeric"},
+	"numeric_limits":     {"limits"},
+	"initializer_list":   {"initializer_list"},
+	"runtime_error":      {"stdexcept"},
+	"logic_error":        {"stdexcept"},
+	"invalid_argument":   {"stdexcept"},
+	"out_of_range":       {"stdexcept"},
+	"exception":          {"exception"},
+	"regex":              {"regex"},
+	"filesystem":         {"filesystem"},
+	"chrono":             {"chrono"},
+}
+
+var (
+	stdSymbolRegex = regexp.MustCompile(`\bstd::(\w+)`)
+	commentsRegex  = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	stringLitRegex = regexp.MustCompile(`"(\\.|[^"\\\n])*"`)
+)
+
+// CheckSelfContained verifies that every project header in g is self-contained, this is,
+// that it would compile on its own without relying on other headers being included
+// before it. The returned violations are ready to be displayed.
+func (l *Language) CheckSelfContained(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+	if l.Cfg == nil {
+		return nil, nil
+	}
+	switch l.Cfg.SelfContained.Mode {
+	case "":
+		return nil, nil
+	case SelfContainedHeuristic:
+		return l.checkSelfContainedHeuristic(g)
+	case SelfContainedCompiler:
+		return l.checkSelfContainedCompiler(g)
+	default:
+		return nil, fmt.Errorf("unknown selfContained mode %q, expected %q or %q", l.Cfg.SelfContained.Mode, SelfContainedHeuristic, SelfContainedCompiler)
+	}
+}
+
+func isHeaderFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext != "" && slices.Contains(HeaderExtensions, ext[1:])
+}
+
+// projectHeaders returns the headers in g that belong to the project, leaving out the
+// ones that live in non-recursive include paths, like the standard library.
+func (l *Language) projectHeaders(g *graph.Graph[*language.FileInfo]) []*graph.Node[*language.FileInfo] {
+	var result []*graph.Node[*language.FileInfo]
+	for _, node := range g.AllNodes() {
+		if !isHeaderFile(node.Id) {
+			continue
+		}
+		isProject := true
+		for _, includePath := range l.Cfg.NonRecursiveIncludePaths {
+			if strings.HasPrefix(node.Id, includePath) {
+				isProject = false
+				break
+			}
+		}
+		if isProject {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+func (l *Language) includedFiles(file *language.FileInfo) []string {
+	statements, ok := file.Content.([]Statement)
+	if !ok {
+		// The file might come from a cache that does not keep the parsed statements.
+		if parsed, err := l.ParseFile(file.AbsPath); err == nil {
+			statements, _ = parsed.Content.([]Statement)
+		}
+	}
+	var result []string
+	for _, statement := range statements {
+		if statement.Quoted != nil {
+			result = append(result, statement.Quoted.IncludedFile)
+		} else if statement.Angled != nil {
+			result = append(result, statement.Angled.IncludedFile)
+		}
+	}
+	return result
+}
+
+// declarationRegexes capture the identifiers declared in a file: classes, structs, unions and
+// enums, both defined and forward declared, type aliases and macros.
+var declarationRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`\b(?:class|struct|union|enum(?:\s+class|\s+struct)?)\s+(\w+)\s*(?:final\s*)?[:{;]`),
+	regexp.MustCompile(`\busing\s+(\w+)\s*=`),
+	regexp.MustCompile(`\btypedef\b[^;]*?\b(\w+)\s*;`),
+	regexp.MustCompile(`(?m)^[ \t]*#[ \t]*define[ \t]+(\w+)`),
+}
+
+var identifierRegex = regexp.MustCompile(`\b[_a-zA-Z]\w*\b`)
+
+// headerCode holds what the heuristic needs to know about the code of a header.
+type headerCode struct {
+	// code is the content of the header without comments and string literals.
+	code string
+	// declared are the identifiers declared in the header.
+	declared map[string]bool
+	// used are all the identifiers that appear in the header.
+	used map[string]bool
+}
+
+func readHeaderCode(path string) (*headerCode, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	code := commentsRegex.ReplaceAllString(string(content), "")
+	code = stringLitRegex.ReplaceAllString(code, `""`)
+
+	result := headerCode{code: code, declared: map[string]bool{}, used: map[string]bool{}}
+	for _, regex := range declarationRegexes {
+		for _, match := range regex.FindAllStringSubmatch(code, -1) {
+			result.declared[match[1]] = true
+		}
+	}
+	for _, identifier := range identifierRegex.FindAllString(code, -1) {
+		result.used[identifier] = true
+	}
+	return &result, nil
+}
+
+// includeLine returns the line where from includes to, or 0 if it is not known.
+func includeLine(g *graph.Graph[*language.FileInfo], from, to string) int {
+	if data, ok := g.EdgeData(from, to).(graph.LineData); ok {
+		return data.Line()
+	}
+	return 0
+}
+
+// includedBefore returns the project headers that every file including node includes
+// directly before it, as these are the ones node might be silently relying on.
+func includedBefore(
+	g *graph.Graph[*language.FileInfo],
+	node *graph.Node[*language.FileInfo],
+	isProject map[string]bool,
+) []string {
+	includers := g.ToId(node.Id)
+	if len(includers) == 0 {
+		return nil
+	}
+	var result []string
+	for i, includer := range includers {
+		line := includeLine(g, includer.Id, node.Id)
+		var before []string
+		for _, dep := range g.FromId(includer.Id) {
+			if depLine := includeLine(g, includer.Id, dep.Id); isProject[dep.Id] && depLine > 0 && depLine < line {
+				before = append(before, dep.Id)
+			}
+		}
+		if i == 0 {
+			result = before
+		} else {
+			result = slices.DeleteFunc(result, func(id string) bool { return !slices.Contains(before, id) })
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+func (l *Language) checkSelfContainedHeuristic(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+	headers := l.projectHeaders(g)
+	isProject := map[string]bool{}
+	codes := map[string]*headerCode{}
+	for _, node := range headers {
+		isProject[node.Id] = true
+		code, err := readHeaderCode(node.Id)
+		if err != nil {
+			return nil, err
+		}
+		codes[node.Id] = code
+	}
+
+	var violations []string
+	for _, node := range headers {
+		code := codes[node.Id]
+		var problems []string
+
+		// Standard library symbols must be declared by one of the directly included headers.
+		available := map[string]bool{}
+		for _, included := range l.includedFiles(node.Data) {
+			available[included] = true
+		}
+		reported := map[string]bool{}
+		for _, match := range stdSymbolRegex.FindAllStringSubmatch(code.code, -1) {
+			symbol := match[1]
+			stdHeaders, ok := stdSymbolHeaders[symbol]
+			if !ok || reported[symbol] {
+				continue
+			}
+			reported[symbol] = true
+			if !slices.ContainsFunc(stdHeaders, func(h string) bool { return available[h] }) {
+				problems = append(problems, fmt.Sprintf("uses std::%s but does not include <%s>", symbol, stdHeaders[0]))
+			}
+		}
+
+		// Identifiers from other project headers are only suspicious if the header does not
+		// include them directly, but every file including it does include them before.
+		included := map[string]bool{node.Id: true}
+		for _, dep := range g.FromId(node.Id) {
+			included[dep.Id] = true
+		}
+		for _, id := range includedBefore(g, node, isProject) {
+			if included[id] {
+				continue
+			}
+			var missing []string
+			for identifier := range codes[id].declared {
+				if code.used[identifier] && !code.declared[identifier] {
+					missing = append(missing, identifier)
+				}
+			}
+			slices.Sort(missing)
+			for _, identifier := range missing {
+				problems = append(problems, fmt.Sprintf("uses %s but does not include %s", identifier, g.Get(id).Data.RelPath))
+			}
+		}
+
+		if len(problems) > 0 {
+			violations = append(violations, node.Data.RelPath+"\n"+strings.Join(problems, "\n"))
+		}
+	}
+	return violations, nil
+}
+
+type compileCommand struct {
+	Directory string   `json:"directory"`
+	Command   string   `json:"command"`
+	Arguments []string `json:"arguments"`
+	File      string   `json:"file"`
+}
+
+func loadCompileCommands(path string) (map[string]compileCommand, error) {
+	result := map[string]compileCommand{}
+	if path == "" {
+		return result, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var commands []compileCommand
+	if err = json.Unmarshal(content, &commands); err != nil {
+		return nil, fmt.Errorf("compile commands file %s is not valid: %w", path, err)
+	}
+	for _, command := range commands {
+		file := command.File
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(command.Directory, file)
+		}
+		result[filepath.Clean(file)] = command
+	}
+	return result, nil
+}
+
+// flagsWithValue are the compiler flags that might take their value as the next argument.
+var flagsWithValue = []string{"-I", "-D", "-U", "-isystem", "-iquote", "-idirafter", "-include", "-imacros"}
+
+// keptFlagPrefixes are the compiler flags that affect how a header is parsed.
+var keptFlagPrefixes = []string{"-I", "-D", "-U", "-isystem", "-iquote", "-idirafter", "-include", "-imacros", "-std", "-f", "-m", "-nostd", "--sysroot", "-stdlib"}
+
+// flags returns the arguments of the compile command that are relevant for parsing
+// a header, discarding the compiler, the inputs and the outputs.
+func (c *compileCommand) flags() []string {
+	args := c.Arguments
+	if len(args) == 0 {
+		args = strings.Fields(c.Command)
+	}
+	var result []string
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if slices.Contains(flagsWithValue, arg) && i+1 < len(args) {
+			result = append(result, arg, args[i+1])
+			i++
+			continue
+		}
+		for _, prefix := range keptFlagPrefixes {
+			if strings.HasPrefix(arg, prefix) {
+				result = append(result, arg)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// translationUnit finds the closest source file that includes node and has a compile command.
+func translationUnit(
+	g *graph.Graph[*language.FileInfo],
+	node *graph.Node[*language.FileInfo],
+	commands map[string]compileCommand,
+) (compileCommand, bool) {
+	visited := map[string]bool{node.Id: true}
+	queue := []*graph.Node[*language.FileInfo]{node}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if command, ok := commands[current.Id]; ok {
+			return command, true
+		}
+		for _, includer := range g.ToId(current.Id) {
+			if !visited[includer.Id] {
+				visited[includer.Id] = true
+				queue = append(queue, includer)
+			}
+		}
+	}
+	return compileCommand{}, false
+}
+
+const maxCompilerOutputLines = 10
+
+func (l *Language) checkSelfContainedCompiler(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+	cfg := l.Cfg.SelfContained
+	compiler := cfg.Compiler
+	if compiler == "" {
+		compiler = "c++"
+	}
+	commands, err := loadCompileCommands(cfg.CompileCommands)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := l.projectHeaders(g)
+	violations := make([]string, len(headers))
+	errs := make([]error, len(headers))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.NumCPU(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				node := headers[i]
+				var args []string
+				cmd := exec.Command(compiler)
+				if command, ok := translationUnit(g, node, commands); ok {
+					args = append(args, command.flags()...)
+					cmd.Dir = command.Directory
+				}
+				args = append(args, cfg.Flags...)
+				cmd.Args = append(append(cmd.Args, args...), "-fsyntax-only", "-x", "c++-header", node.Id)
+
+				out, err := cmd.CombinedOutput()
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+					if len(lines) > maxCompilerOutputLines {
+						lines = append(lines[:maxCompilerOutputLines], "...")
+					}
+					violations[i] = node.Data.RelPath + "\n" + strings.Join(lines, "\n")
+				} else if err != nil {
+					errs[i] = fmt.Errorf("could not compile %s: %w", node.Data.RelPath, err)
+				}
+			}
+		}()
+	}
+	for i := range headers {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err = errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(violations, func(v string) bool { return v == "" }), nil
+}
@@ -0,0 +1,108 @@
+package cpp
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+const selfContainedFolder = ".test_files/self_contained"
+
+func loadSelfContainedGraph(t *testing.T, cfg SelfContainedConfig) (*Language, *graph.Graph[*language.FileInfo]) {
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, selfContainedFolder)
+
+	lang, err := MakeCppLanguage(&Config{
+		RecursiveIncludePaths: []string{filepath.Join(root, "include"), root},
+		SelfContained:         cfg,
+	})
+	a.NoError(err)
+
+	g := graph.NewGraph[*language.FileInfo]()
+	err = g.Load([]string{filepath.Join(root, "main.cpp")}, language.NewParser(lang), nil)
+	a.NoError(err)
+	return lang.(*Language), g
+}
+
+func TestCheckSelfContained_Heuristic(t *testing.T) {
+	a := require.New(t)
+	lang, g := loadSelfContainedGraph(t, SelfContainedConfig{Mode: SelfContainedHeuristic})
+
+	violations, err := lang.CheckSelfContained(g)
+	a.NoError(err)
+	include := filepath.Join(selfContainedFolder, "include")
+	a.Equal([]string{
+		filepath.Join(include, "a.h") + "\nuses std::string but does not include <string>",
+		// <string> is only included transitively through c.h.
+		filepath.Join(include, "b.h") + "\nuses std::string but does not include <string>",
+		filepath.Join(include, "shape.h") + "\nuses Point but does not include " + filepath.Join(include, "types.h"),
+	}, violations)
+}
+
+func TestCheckSelfContained_Disabled(t *testing.T) {
+	a := require.New(t)
+	lang, g := loadSelfContainedGraph(t, SelfContainedConfig{})
+
+	violations, err := lang.CheckSelfContained(g)
+	a.NoError(err)
+	a.Empty(violations)
+}
+
+func TestCheckSelfContained_UnknownMode(t *testing.T) {
+	a := require.New(t)
+	lang, g := loadSelfContainedGraph(t, SelfContainedConfig{Mode: "foo"})
+
+	_, err := lang.CheckSelfContained(g)
+	a.ErrorContains(err, `unknown selfContained mode "foo"`)
+}
+
+func TestCheckSelfContained_Compiler(t *testing.T) {
+	if _, err := exec.LookPath("c++"); err != nil {
+		t.Skip("no c++ compiler available")
+	}
+	a := require.New(t)
+	wd, _ := os.Getwd()
+	root := filepath.Join(wd, selfContainedFolder)
+
+	// The include path is only available through the flags of the translation unit.
+	compileCommands := filepath.Join(t.TempDir(), "compile_commands.json")
+	content, err := json.Marshal([]compileCommand{{
+		Directory: root,
+		Arguments: []string{"c++", "-Iinclude", "-std=c++17", "-c", "main.cpp", "-o", "main.o"},
+		File:      "main.cpp",
+	}})
+	a.NoError(err)
+	a.NoError(os.WriteFile(compileCommands, content, 0o600))
+
+	lang, g := loadSelfContainedGraph(t, SelfContainedConfig{
+		Mode:            SelfContainedCompiler,
+		CompileCommands: compileCommands,
+	})
+
+	violations, err := lang.CheckSelfContained(g)
+	a.NoError(err)
+	include := filepath.Join(selfContainedFolder, "include")
+	// widget.h fails too, as it includes circle.h, which is not self-contained.
+	expected := []struct{ header, symbol string }{{"a.h", "string"}, {"shape.h", "Point"}, {"circle.h", "Point"}, {"widget.h", "Point"}}
+	a.Len(violations, len(expected))
+	for i, e := range expected {
+		a.Contains(violations[i], filepath.Join(include, e.header)+"\n")
+		a.Contains(violations[i], e.symbol)
+	}
+}
+
+func TestCompileCommand_Flags(t *testing.T) {
+	a := require.New(t)
+	command := compileCommand{
+		Command: "/usr/bin/c++ -I include -isystem /opt/include -DFOO=1 -std=c++20 -fno-exceptions -Wall -O2 -c src/main.cpp -o main.o -MD -MF main.d",
+	}
+	a.Equal([]string{"-I", "include", "-isystem", "/opt/include", "-DFOO=1", "-std=c++20", "-fno-exceptions"}, command.flags())
+}
@@ -0,0 +1,137 @@
+package diff
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/check"
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+// Snapshot is the dependency graph of a project at some point in time.
+type Snapshot[T any] struct {
+	Graph *graph.Graph[T]
+	// Key identifies a node across snapshots, like its path relative to the project's root.
+	Key func(node *graph.Node[T]) string
+	// Violations are the check violations found in the graph.
+	Violations []check.Violation
+}
+
+// Edge is a dependency between two nodes, identified by their keys.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Diff holds the changes between two snapshots. All the fields are sorted.
+type Diff struct {
+	AddedFiles          []string          `json:"addedFiles"`
+	RemovedFiles        []string          `json:"removedFiles"`
+	AddedDependencies   []Edge            `json:"addedDependencies"`
+	RemovedDependencies []Edge            `json:"removedDependencies"`
+	NewCycles           [][]string        `json:"newCycles"`
+	ResolvedCycles      [][]string        `json:"resolvedCycles"`
+	NewViolations       []check.Violation `json:"newViolations"`
+	ResolvedViolations  []check.Violation `json:"resolvedViolations"`
+}
+
+// IsEmpty tells whether there are no changes at all.
+func (d Diff) IsEmpty() bool {
+	return len(d.AddedFiles) == 0 && len(d.RemovedFiles) == 0 &&
+		len(d.AddedDependencies) == 0 && len(d.RemovedDependencies) == 0 &&
+		len(d.NewCycles) == 0 && len(d.ResolvedCycles) == 0 &&
+		len(d.NewViolations) == 0 && len(d.ResolvedViolations) == 0
+}
+
+// Compare computes the changes needed for going from before to after.
+func Compare[T any](before Snapshot[T], after Snapshot[T]) Diff {
+	var result Diff
+	result.AddedFiles, result.RemovedFiles = difference(files(before), files(after), strings.Compare)
+	result.AddedDependencies, result.RemovedDependencies = difference(edges(before), edges(after), compareEdges)
+	result.NewCycles, result.ResolvedCycles = difference(cycles(before), cycles(after), slices.Compare[[]string])
+	result.NewViolations, result.ResolvedViolations = difference(
+		slices.Clone(before.Violations),
+		slices.Clone(after.Violations),
+		compareViolations,
+	)
+	return result
+}
+
+// difference returns the elements that are only in after and the ones that are only in before,
+// sorted with compare. The slices are sorted in place.
+func difference[E any](before []E, after []E, compare func(a, b E) int) (added []E, removed []E) {
+	slices.SortFunc(before, compare)
+	slices.SortFunc(after, compare)
+	added, removed = make([]E, 0), make([]E, 0)
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case j == len(after) || (i < len(before) && compare(before[i], after[j]) < 0):
+			removed = append(removed, before[i])
+			i++
+		case i == len(before) || compare(before[i], after[j]) > 0:
+			added = append(added, after[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return added, removed
+}
+
+func files[T any](s Snapshot[T]) []string {
+	nodes := s.Graph.AllNodes()
+	result := make([]string, len(nodes))
+	for i, node := range nodes {
+		result[i] = s.Key(node)
+	}
+	return result
+}
+
+func edges[T any](s Snapshot[T]) []Edge {
+	var result []Edge
+	for _, node := range s.Graph.AllNodes() {
+		for _, dep := range s.Graph.FromId(node.Id) {
+			result = append(result, Edge{From: s.Key(node), To: s.Key(dep)})
+		}
+	}
+	return result
+}
+
+// cycles returns the shortest cycle that goes through each dependency that is part of one,
+// with the keys of the nodes and starting from the smallest one, so that they can be compared
+// across snapshots.
+func cycles[T any](s Snapshot[T]) [][]string {
+	var result [][]string
+	for _, component := range s.Graph.CyclicComponents(math.MaxInt) {
+		for _, cycle := range component.Cycles {
+			keys := make([]string, len(cycle)-1)
+			for i, id := range cycle[:len(cycle)-1] {
+				keys[i] = s.Key(s.Graph.Get(id))
+			}
+			start := 0
+			for i, key := range keys {
+				if key < keys[start] {
+					start = i
+				}
+			}
+			keys = append(slices.Clone(keys[start:]), keys[:start]...)
+			result = append(result, append(keys, keys[0]))
+		}
+	}
+	return result
+}
+
+func compareEdges(a, b Edge) int {
+	if c := cmp.Compare(a.From, b.From); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.To, b.To)
+}
+
+func compareViolations(a, b check.Violation) int {
+	return compareEdges(Edge{From: a.From, To: a.To}, Edge{From: b.From, To: b.To})
+}
@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/check"
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+func makeSnapshot(t *testing.T, edges map[string][]string, violations ...check.Violation) Snapshot[string] {
+	// Nodes and edges are added in a deterministic order.
+	var froms []string
+	for from := range edges {
+		froms = append(froms, from)
+	}
+	slices.Sort(froms)
+
+	g := graph.NewGraph[string]()
+	for _, from := range froms {
+		for _, id := range append([]string{from}, edges[from]...) {
+			// Ids are prefixed, so that keys are needed for matching nodes.
+			if !g.Has("/tmp/" + id) {
+				g.AddNode(graph.MakeNode("/tmp/"+id, id))
+			}
+		}
+	}
+	for _, from := range froms {
+		for _, to := range edges[from] {
+			require.NoError(t, g.AddFromToEdge("/tmp/"+from, "/tmp/"+to))
+		}
+	}
+	return Snapshot[string]{
+		Graph:      g,
+		Key:        func(node *graph.Node[string]) string { return node.Data },
+		Violations: violations,
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := require.New(t)
+	before := makeSnapshot(
+		t,
+		map[string][]string{
+			"main": {"a", "b"},
+			"a":    {"b"},
+			"b":    {"a"},
+			"old":  {},
+		},
+		check.Violation{From: "main", To: "b"},
+	)
+	after := makeSnapshot(
+		t,
+		map[string][]string{
+			"main": {"a", "b", "new"},
+			"a":    {},
+			"b":    {"new"},
+			"new":  {"b"},
+		},
+		check.Violation{From: "main", To: "new", Reason: "new is private"},
+		check.Violation{From: "main", To: "b"},
+	)
+
+	d := Compare(before, after)
+	a.Equal([]string{"new"}, d.AddedFiles)
+	a.Equal([]string{"old"}, d.RemovedFiles)
+	a.Equal([]Edge{{"b", "new"}, {"main", "new"}, {"new", "b"}}, d.AddedDependencies)
+	a.Equal([]Edge{{"a", "b"}, {"b", "a"}}, d.RemovedDependencies)
+	a.Equal([][]string{{"b", "new", "b"}}, d.NewCycles)
+	a.Equal([][]string{{"a", "b", "a"}}, d.ResolvedCycles)
+	a.Equal([]check.Violation{{From: "main", To: "new", Reason: "new is private"}}, d.NewViolations)
+	a.Equal([]check.Violation{}, d.ResolvedViolations)
+	a.False(d.IsEmpty())
+
+	a.True(Compare(before, before).IsEmpty())
+}
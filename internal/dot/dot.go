@@ -0,0 +1,117 @@
+package dot
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// highlightColor is the color of the dependencies that are part of a cycle and of the nodes
+// with errors.
+const highlightColor = "red"
+
+// Render writes the whole graph in the Graphviz DOT language. Nodes are clustered into
+// subgraphs by clusterBy, unless it is nil, and the dependencies that are part of a cycle
+// are drawn in red. Nodes carry their lines of code, and the ones with errors are drawn in
+// red too, with the errors in their tooltip. Dependencies that represent more than one import,
+// like between groups of files, are labeled with the amount of imports.
+func Render(
+	g *graph.Graph[*language.FileInfo],
+	display func(node *graph.Node[*language.FileInfo]) string,
+	clusterBy language.GroupBy,
+) string {
+	nodes := g.AllNodes()
+	slices.SortFunc(nodes, func(a, b *graph.Node[*language.FileInfo]) int {
+		return cmp.Compare(display(a), display(b))
+	})
+	componentOf := map[string]int{}
+	for i, component := range g.CyclicComponents(0) {
+		for _, id := range component.Nodes {
+			componentOf[id] = i
+		}
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("digraph dependencies {\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	if clusterBy == nil {
+		for _, node := range nodes {
+			writeNode(&sb, "  ", node, display)
+		}
+	} else {
+		var clusters []string
+		byCluster := map[string][]*graph.Node[*language.FileInfo]{}
+		for _, node := range nodes {
+			cluster := clusterBy(node.Data)
+			if _, ok := byCluster[cluster]; !ok {
+				clusters = append(clusters, cluster)
+			}
+			byCluster[cluster] = append(byCluster[cluster], node)
+		}
+		slices.Sort(clusters)
+		for i, cluster := range clusters {
+			sb.WriteString(fmt.Sprintf("  subgraph cluster_%d {\n", i))
+			sb.WriteString(fmt.Sprintf("    label=%s;\n", quote(cluster)))
+			for _, node := range byCluster[cluster] {
+				writeNode(&sb, "    ", node, display)
+			}
+			sb.WriteString("  }\n")
+		}
+	}
+
+	for _, node := range nodes {
+		deps := g.FromId(node.Id)
+		slices.SortFunc(deps, func(a, b *graph.Node[*language.FileInfo]) int {
+			return cmp.Compare(display(a), display(b))
+		})
+		for _, dep := range deps {
+			sb.WriteString(fmt.Sprintf("  %s -> %s", quote(display(node)), quote(display(dep))))
+			var attrs []string
+			if weight := g.Weight(node.Id, dep.Id); weight > 1 {
+				attrs = append(attrs, fmt.Sprintf("label=%d", weight))
+			}
+			fromComponent, fromCyclic := componentOf[node.Id]
+			toComponent, toCyclic := componentOf[dep.Id]
+			// All the dependencies within a strongly connected component are part of a cycle.
+			if fromCyclic && toCyclic && fromComponent == toComponent {
+				attrs = append(attrs, "color="+highlightColor)
+			}
+			if len(attrs) > 0 {
+				sb.WriteString(" [" + strings.Join(attrs, ", ") + "]")
+			}
+			sb.WriteString(";\n")
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func writeNode(
+	sb *strings.Builder,
+	indent string,
+	node *graph.Node[*language.FileInfo],
+	display func(node *graph.Node[*language.FileInfo]) string,
+) {
+	attrs := []string{fmt.Sprintf("loc=%d", node.Data.Loc)}
+	if len(node.Errors) > 0 {
+		errs := make([]string, len(node.Errors))
+		for i, err := range node.Errors {
+			errs[i] = err.Error()
+		}
+		attrs = append(attrs, "color="+highlightColor, "tooltip="+quote(strings.Join(errs, "\n")))
+	}
+	sb.WriteString(fmt.Sprintf("%s%s [%s];\n", indent, quote(display(node)), strings.Join(attrs, ", ")))
+}
+
+// quote turns s into a DOT string, escaping the characters that have a special meaning.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,53 @@
+package dot
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/utils"
+)
+
+const testDir = ".dot_test"
+
+func TestRender(t *testing.T) {
+	a := require.New(t)
+	g := graph.NewGraph[*language.FileInfo]()
+	for file, loc := range map[string]int{
+		"main.py":         10,
+		"users/api.py":    50,
+		"users/model.py":  30,
+		"orders/api.py":   40,
+		`orders/"odd".py`: 5,
+	} {
+		g.AddNode(graph.MakeNode(file, &language.FileInfo{RelPath: file, Loc: loc}))
+	}
+	a.NoError(g.AddFromToEdge("main.py", "users/api.py", "orders/api.py"))
+	a.NoError(g.AddFromToEdge("users/api.py", "users/model.py"))
+	a.NoError(g.AddFromToEdge("users/model.py", "orders/api.py"))
+	a.NoError(g.AddFromToEdge("orders/api.py", "users/api.py", `orders/"odd".py`))
+	a.NoError(g.SetWeight("main.py", "users/api.py", 3))
+	g.Get(`orders/"odd".py`).AddErrors(errors.New("could not parse"), errors.New("unknown import"))
+	display := func(node *graph.Node[*language.FileInfo]) string { return node.Data.RelPath }
+	groupBy, err := language.ParseGroupBy("dir")
+	a.NoError(err)
+
+	tests := []struct {
+		Name      string
+		ClusterBy language.GroupBy
+	}{
+		{Name: "no clusters"},
+		{Name: "clusters", ClusterBy: groupBy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			result := Render(g, display, tt.ClusterBy)
+			utils.GoldenTest(t, filepath.Join(testDir, tt.Name+".dot"), result)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/gabotechs/dep-tree/internal/cochange"
 	"github.com/gabotechs/dep-tree/internal/graph"
 	"github.com/gabotechs/dep-tree/internal/language"
 	"github.com/gabotechs/dep-tree/internal/utils"
@@ -30,17 +31,49 @@ type Link struct {
 	From     int64 `json:"from"`
 	To       int64 `json:"to"`
 	IsCyclic bool  `json:"isCyclic"`
+	// Weight is how many imports the link represents, only set if there is more than one.
+	Weight int `json:"weight,omitempty"`
+	// Line is the line in the source file where the dependency is imported.
+	Line int `json:"line,omitempty"`
+	// Symbols are the imported symbols, "*" meaning all of them.
+	Symbols []string `json:"symbols,omitempty"`
+	// Kinds are the kinds of import, like "type-only" or "dynamic".
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// setDependency fills the link with what the dependency imports and how.
+func (l *Link) setDependency(data any) {
+	dep, ok := data.(*language.Dependency)
+	if !ok {
+		return
+	}
+	l.Line = dep.Line()
+	l.Symbols = dep.Imported()
+	l.Kinds = dep.Kinds()
+}
+
+// CoChange is a pair of files that often changed together in the git history.
+type CoChange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+	// CoChanges is the amount of commits in which both files changed.
+	CoChanges int `json:"coChanges"`
+	// Coupling is how often the files changed together, from 0 to 1.
+	Coupling float64 `json:"coupling"`
+	// Dependent tells whether one of the files depends on the other one.
+	Dependent bool `json:"dependent"`
 }
 
 type Graph struct {
-	Nodes     []Node `json:"nodes"`
-	Links     []Link `json:"links"`
-	EnableGui bool   `json:"enableGui"`
+	Nodes     []Node     `json:"nodes"`
+	Links     []Link     `json:"links"`
+	CoChanges []CoChange `json:"coChanges,omitempty"`
+	EnableGui bool       `json:"enableGui"`
 }
 
-func makeGraph(files []string, parser graph.NodeParser[*language.FileInfo], loadCallbacks graph.LoadCallbacks[*language.FileInfo]) (Graph, error) {
+func makeGraph(files []string, parser graph.NodeParser[*language.FileInfo], cfg RenderConfig) (Graph, error) {
 	g := graph.NewGraph[*language.FileInfo]()
-	err := g.Load(files, parser, loadCallbacks)
+	err := g.Load(files, parser, cfg.LoadCallbacks)
 	if err != nil {
 		return Graph{}, err
 	}
@@ -57,10 +90,31 @@ func makeGraph(files []string, parser graph.NodeParser[*language.FileInfo], load
 		entrypoints = g.GetNodesWithoutParents()
 	}
 
+	// Computed before any edge is removed, as they tell whether the files depend on each other.
+	var coChanges []CoChange
+	if cfg.ChangeSets != nil {
+		report := cochange.Analyze(g, cfg.ChangeSets, cfg.CoChange)
+		for _, pair := range report.Pairs {
+			coChanges = append(coChanges, CoChange{
+				From:      g.Get(pair.A).ID(),
+				To:        g.Get(pair.B).ID(),
+				CoChanges: pair.CoChanges,
+				Coupling:  pair.Coupling,
+				Dependent: pair.Dependent,
+			})
+		}
+	}
+
 	cycles := g.RemoveCycles(entrypoints)
+	if cfg.Reduce {
+		if _, err = g.TransitiveReduction(); err != nil {
+			return Graph{}, err
+		}
+	}
 	out := Graph{
-		Nodes: make([]Node, 0),
-		Links: make([]Link, 0),
+		Nodes:     make([]Node, 0),
+		Links:     make([]Link, 0),
+		CoChanges: coChanges,
 	}
 
 	allNodes := g.AllNodes()
@@ -83,19 +137,30 @@ func makeGraph(files []string, parser graph.NodeParser[*language.FileInfo], load
 		out.Nodes = append(out.Nodes, n)
 
 		for _, to := range g.FromId(node.Id) {
-			out.Links = append(out.Links, Link{
+			link := Link{
 				From: node.ID(),
 				To:   to.ID(),
-			})
+			}
+			if weight := g.Weight(node.Id, to.Id); weight > 1 {
+				link.Weight = weight
+			}
+			link.setDependency(g.EdgeData(node.Id, to.Id))
+			out.Links = append(out.Links, link)
 		}
 	}
 
+	// The edges that cause cycles were removed from the graph, together with their data.
+	dataParser, withData := parser.(graph.EdgeDataNodeParser[*language.FileInfo])
 	for _, cycle := range cycles {
-		out.Links = append(out.Links, Link{
-			From:     graph.MakeNode(cycle.Cause[0], 0).ID(),
-			To:       graph.MakeNode(cycle.Cause[1], 0).ID(),
+		link := Link{
+			From:     g.Get(cycle.Cause[0]).ID(),
+			To:       g.Get(cycle.Cause[1]).ID(),
 			IsCyclic: true,
-		})
+		}
+		if withData {
+			link.setDependency(dataParser.EdgeData(cycle.Cause[0], cycle.Cause[1]))
+		}
+		out.Links = append(out.Links, link)
 	}
 
 	return out, nil
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gabotechs/dep-tree/internal/cochange"
 	"github.com/gabotechs/dep-tree/internal/graph"
 	"github.com/gabotechs/dep-tree/internal/language"
 )
@@ -19,14 +20,20 @@ const ToReplace = `"__INLINE_DATA",{}`
 const ReplacePrefix = `"__INLINE_DATA",`
 
 type RenderConfig struct {
-	NoOpen        bool
-	EnableGui     bool
-	RenderPath    string
+	NoOpen     bool
+	EnableGui  bool
+	RenderPath string
+	// Reduce only renders the links that are not already implied by longer paths.
+	Reduce bool
+	// ChangeSets are the files changed by each commit in the git history. If set, the pairs of
+	// files that often changed together are added to the graph.
+	ChangeSets    [][]string
+	CoChange      cochange.Options
 	LoadCallbacks graph.LoadCallbacks[*language.FileInfo]
 }
 
 func Render(files []string, parser graph.NodeParser[*language.FileInfo], cfg RenderConfig) error {
-	graph3d, err := makeGraph(files, parser, cfg.LoadCallbacks)
+	graph3d, err := makeGraph(files, parser, cfg)
 	if err != nil {
 		return err
 	}
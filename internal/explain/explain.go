@@ -5,12 +5,20 @@ import (
 	"github.com/gabotechs/dep-tree/internal/utils"
 )
 
+// Dependency is an edge from one of the files at the left to one of the files at the right.
+type Dependency[T any] struct {
+	From *graph.Node[T]
+	To   *graph.Node[T]
+	// Data is the payload of the edge, if the parser provides one.
+	Data any
+}
+
 func Explain[T any](
 	parser graph.NodeParser[T],
 	fromFiles []string,
 	toFiles []string,
 	callbacks graph.LoadCallbacks[T],
-) ([][2]*graph.Node[T], error) {
+) ([]Dependency[T], error) {
 	// 1. Build the graph.
 	g := graph.NewGraph[T]()
 	err := g.Load(append(fromFiles, toFiles...), parser, callbacks)
@@ -22,13 +30,13 @@ func Explain[T any](
 	fromSet := utils.SetFromSlice(fromFiles)
 
 	nodes := g.AllNodes()
-	var deps [][2]*graph.Node[T]
+	var deps []Dependency[T]
 	for _, node := range nodes {
 		if fromSet.Has(node.Id) {
 			for _, toFile := range toFiles {
 				toNode := g.Get(toFile)
 				if toNode != nil && g.HasEdgeFromTo(node.ID(), toNode.ID()) {
-					deps = append(deps, [2]*graph.Node[T]{node, toNode})
+					deps = append(deps, Dependency[T]{node, toNode, g.EdgeData(node.Id, toNode.Id)})
 				}
 			}
 		}
@@ -74,7 +74,7 @@ func TestExplain(t *testing.T) {
 			a.NoError(err)
 			rendered := make([]string, len(result))
 			for i, r := range result {
-				rendered[i] = r[0].Id + " -> " + r[1].Id
+				rendered[i] = r.From.Id + " -> " + r.To.Id
 			}
 			a.Equal(tt.Expected, rendered)
 		})
@@ -2,6 +2,7 @@ package golang
 
 import (
 	"os"
+	"strings"
 
 	"github.com/gabotechs/dep-tree/internal/utils"
 	"golang.org/x/mod/modfile"
@@ -9,6 +10,8 @@ import (
 
 type GoMod struct {
 	Module string
+	// Require are the paths of the modules required by this one.
+	Require []string
 }
 
 func _ParseGoMod(file string) (*GoMod, error) {
@@ -20,9 +23,34 @@ func _ParseGoMod(file string) (*GoMod, error) {
 	if err != nil {
 		return nil, err
 	}
+	require := make([]string, len(goMod.Require))
+	for i, req := range goMod.Require {
+		require[i] = req.Mod.Path
+	}
 	return &GoMod{
-		Module: goMod.Module.Mod.Path,
+		Module:  goMod.Module.Mod.Path,
+		Require: require,
 	}, nil
 }
 
+// ExternalModule returns the path of the third-party module that provides the package imported
+// with importPath, or an empty string if the package is from the standard library.
+func (g *GoMod) ExternalModule(importPath string) string {
+	first, _, _ := strings.Cut(importPath, "/")
+	if !strings.Contains(first, ".") {
+		return ""
+	}
+	module := ""
+	for _, req := range g.Require {
+		if (importPath == req || strings.HasPrefix(importPath, req+"/")) && len(req) > len(module) {
+			module = req
+		}
+	}
+	if module == "" {
+		// The module might be replaced or vendored, the package path is the best guess then.
+		return importPath
+	}
+	return module
+}
+
 var ParseGoMod = utils.Cached1In1OutErr(_ParseGoMod)
@@ -24,7 +24,33 @@ func TestGoMod(t *testing.T) {
 			a := require.New(t)
 			result, err := ParseGoMod(tt.Name)
 			a.NoError(err)
-			a.Equal(tt.Expected, *result)
+			a.Equal(tt.Expected.Module, result.Module)
+			a.Contains(result.Require, "golang.org/x/mod")
+		})
+	}
+}
+
+func TestGoMod_ExternalModule(t *testing.T) {
+	goMod := GoMod{
+		Module:  "github.com/foo/bar",
+		Require: []string{"golang.org/x/mod", "github.com/go-git/go-git/v5", "github.com/go-git/go-git/v5/plumbing"},
+	}
+	tests := []struct {
+		ImportPath string
+		Expected   string
+	}{
+		{ImportPath: "fmt", Expected: ""},
+		{ImportPath: "path/filepath", Expected: ""},
+		{ImportPath: "golang.org/x/mod", Expected: "golang.org/x/mod"},
+		{ImportPath: "golang.org/x/mod/modfile", Expected: "golang.org/x/mod"},
+		{ImportPath: "golang.org/x/module", Expected: "golang.org/x/module"},
+		{ImportPath: "github.com/go-git/go-git/v5/plumbing/object", Expected: "github.com/go-git/go-git/v5/plumbing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ImportPath, func(t *testing.T) {
+			a := require.New(t)
+			a.Equal(tt.Expected, goMod.ExternalModule(tt.ImportPath))
 		})
 	}
 }
@@ -21,6 +21,11 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 		importStmt := NewImportStmt(importSpec)
 
 		if !importStmt.IsLocal(thisModule) {
+			if module := l.GoMod.ExternalModule(importStmt.ImportPath); module != "" {
+				entry := language.ExternalImport(language.GoEcosystem, module)
+				entry.Line = content.line(importSpec.Pos())
+				result.Imports = append(result.Imports, entry)
+			}
 			continue
 		}
 		pkgs, err := PackagesInDir(filepath.Join(l.Root.AbsDir, importStmt.RelPath(thisModule)))
@@ -56,7 +61,9 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 
 		for _, pkg := range pkgLookup {
 			if f, ok := pkg.SymbolToFile[unresolved.Name]; ok {
-				result.Imports = append(result.Imports, language.SymbolsImport([]string{unresolved.Name}, f.AbsPath))
+				entry := language.SymbolsImport([]string{unresolved.Name}, f.AbsPath)
+				entry.Line = content.line(unresolved.Pos())
+				result.Imports = append(result.Imports, entry)
 				nonQualifiedResolutions[unresolved.Name] = struct{}{}
 				break
 			}
@@ -106,10 +113,9 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 				return true
 			}
 
-			result.Imports = append(result.Imports, language.SymbolsImport(
-				[]string{selectorExpr.Sel.Name},
-				absPath,
-			))
+			entry := language.SymbolsImport([]string{selectorExpr.Sel.Name}, absPath)
+			entry.Line = content.line(selectorExpr.Pos())
+			result.Imports = append(result.Imports, entry)
 			fullyQualifiedResolutions[key] = struct{}{}
 			return true
 		})
@@ -15,13 +15,16 @@ type dummy = Language
 
 func TestImports(t *testing.T) {
 	tests := []struct {
-		Name     string
-		Expected [][2]string
+		Name             string
+		Expected         [][2]string
+		ExpectedExternal []string
 	}{
 		{
 			Name: "imports.go",
 			Expected: [][2]string{
 				{"SymbolsImport", "internal/language/language.go"},
+				{"ExternalImport", "internal/language/external.go"},
+				{"GoEcosystem", "internal/language/external.go"},
 				{"Package", "internal/go/package.go"},
 				{"PackagesInDir", "internal/go/package.go"},
 				{"Language", "internal/go/language.go"},
@@ -54,7 +57,9 @@ func TestImports(t *testing.T) {
 				{"ImportStmt", "internal/go/imports.go"},
 				{"Config", "internal/go/config.go"},
 				{"Language", "internal/language/language.go"},
+				{"IsExternal", "internal/language/external.go"},
 			},
+			ExpectedExternal: []string{"go:github.com/stretchr/testify"},
 		},
 	}
 
@@ -69,8 +74,14 @@ func TestImports(t *testing.T) {
 			a.NoError(err)
 
 			var actual [][2]string
+			var actualExternal []string
 			for _, imp := range imports.Imports {
+				if IsExternal(imp.AbsPath) {
+					actualExternal = append(actualExternal, imp.AbsPath)
+					continue
+				}
 				a.Equal(1, len(imp.Symbols))
+				a.Positive(imp.Line)
 				actual = append(actual, [2]string{imp.Symbols[0], imp.AbsPath})
 			}
 
@@ -89,6 +100,7 @@ func TestImports(t *testing.T) {
 			})
 
 			a.Equal(expected, actual)
+			a.Equal(tt.ExpectedExternal, actualExternal)
 		})
 	}
 }
@@ -3,6 +3,7 @@ package golang
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"path/filepath"
 
 	"github.com/gabotechs/dep-tree/internal/language"
@@ -35,16 +36,14 @@ func NewLanguage(dir string, cfg *Config) (*Language, error) {
 	}
 
 	return &Language{
-		Cfg:   cfg,
-		GoMod: *goMod,
-		Root:  *sourcesRoot,
+		Cfg:      cfg,
+		GoMod:    *goMod,
+		Root:     *sourcesRoot,
+		Packages: make(map[string]*ast.Package),
 	}, nil
 }
 
 func (l *Language) ParseFile(path string) (*language.FileInfo, error) {
-	if l.Packages == nil {
-		l.Packages = make(map[string]*ast.Package)
-	}
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return nil, err
@@ -56,17 +55,41 @@ func (l *Language) ParseFile(path string) (*language.FileInfo, error) {
 	}
 
 	relPath, _ := filepath.Rel(l.Root.AbsDir, absPath)
+	types, abstractTypes := countTypes(file.AstFile)
 
 	return &language.FileInfo{
-		Content: file,
-		AbsPath: absPath,
-		RelPath: relPath,
-		Package: file.Package.Name,
-		Size:    file.TokenFile.Size(),
-		Loc:     file.TokenFile.LineCount(),
+		Content:       file,
+		AbsPath:       absPath,
+		RelPath:       relPath,
+		Package:       file.Package.Name,
+		Size:          file.TokenFile.Size(),
+		Loc:           file.TokenFile.LineCount(),
+		Types:         types,
+		AbstractTypes: abstractTypes,
 	}, nil
 }
 
+// countTypes counts the exported type declarations, interfaces being the abstract ones.
+func countTypes(file *ast.File) (types int, abstractTypes int) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if !typeSpec.Name.IsExported() {
+				continue
+			}
+			types++
+			if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+				abstractTypes++
+			}
+		}
+	}
+	return types, abstractTypes
+}
+
 var findClosestDirWithRootFile = utils.MakeCachedFindClosestDirWithRootFile([]string{
 	// NOTE: for now, only support projects that contain a go.mod file.
 	"go.mod",
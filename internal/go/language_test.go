@@ -1,6 +1,8 @@
 package golang
 
 import (
+	"go/parser"
+	"go/token"
 	"path/filepath"
 	"testing"
 
@@ -42,3 +44,23 @@ func TestParseFile(t *testing.T) {
 		})
 	}
 }
+
+func TestCountTypes(t *testing.T) {
+	a := require.New(t)
+	file, err := parser.ParseFile(token.NewFileSet(), "", `package foo
+
+type Shape interface{}
+
+type (
+	Square struct{}
+	Id     string
+	private struct{}
+)
+
+func Area() {}
+`, 0)
+	a.NoError(err)
+	types, abstractTypes := countTypes(file)
+	a.Equal(3, types)
+	a.Equal(1, abstractTypes)
+}
@@ -17,6 +17,14 @@ type File struct {
 	AbsPath   string
 }
 
+// line returns the line of pos in the file, or 0 if it is not known.
+func (f *File) line(pos token.Pos) int {
+	if f.TokenFile == nil || !pos.IsValid() {
+		return 0
+	}
+	return f.TokenFile.Line(pos)
+}
+
 func _newFile(path string) (*File, error) {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
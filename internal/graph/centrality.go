@@ -0,0 +1,37 @@
+package graph
+
+import (
+	"gonum.org/v1/gonum/graph/network"
+)
+
+const (
+	pageRankDamping   = 0.85
+	pageRankTolerance = 1e-10
+)
+
+// Centrality measures how central a node is in the graph.
+type Centrality struct {
+	// PageRank is higher for the nodes that are depended on by a lot of nodes, specially if
+	// those are also depended on by a lot of nodes. All the values add up to 1.
+	PageRank float64
+	// Betweenness is the amount of shortest paths between any other two nodes that go
+	// through the node.
+	Betweenness float64
+}
+
+// Centrality computes the centrality of every node in the graph.
+func (g *Graph[T]) Centrality() map[string]Centrality {
+	result := make(map[string]Centrality, g.nodes.Len())
+	if g.nodes.Len() == 0 {
+		return result
+	}
+	pageRank := network.PageRankSparse(g, pageRankDamping, pageRankTolerance)
+	betweenness := network.Betweenness(g)
+	for _, node := range g.AllNodes() {
+		result[node.Id] = Centrality{
+			PageRank:    pageRank[node.ID()],
+			Betweenness: betweenness[node.ID()],
+		}
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_Centrality(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{
+		0: {1, 2},
+		1: {3},
+		2: {3},
+		3: {4},
+		4: {},
+	})
+	centrality := g.Centrality()
+	a.Len(centrality, 5)
+
+	total := 0.0
+	for _, c := range centrality {
+		total += c.PageRank
+	}
+	a.InDelta(1, total, 1e-6)
+	// Everything ends up in 4, passing through 3.
+	a.Greater(centrality["4"].PageRank, centrality["3"].PageRank)
+	a.Greater(centrality["3"].PageRank, centrality["1"].PageRank)
+	a.InDelta(centrality["1"].PageRank, centrality["2"].PageRank, 1e-6)
+
+	a.Equal(0.0, centrality["0"].Betweenness)
+	// Half of the shortest paths from 0 to 3 and 4 go through 1, and the other half through 2.
+	a.Equal(1.0, centrality["1"].Betweenness)
+	a.Equal(1.0, centrality["2"].Betweenness)
+	// All the shortest paths from 0, 1 and 2 to 4 go through 3.
+	a.Equal(3.0, centrality["3"].Betweenness)
+	a.Equal(0.0, centrality["4"].Betweenness)
+}
+
+func TestGraph_Centrality_Empty(t *testing.T) {
+	a := require.New(t)
+	a.Empty(NewGraph[int]().Centrality())
+}
@@ -0,0 +1,53 @@
+package graph
+
+// Collapse builds a new graph where all the nodes of g that share the same key are merged
+// into a single node, whose id is the key and whose data is built by merge. The dependencies
+// between merged nodes are weighted by the amount of edges they represent, and the ones between
+// nodes with the same key are dropped. Errors in the original nodes are carried over.
+func Collapse[T any](
+	g *Graph[T],
+	key func(node *Node[T]) string,
+	merge func(key string, nodes []*Node[T]) T,
+) *Graph[T] {
+	keys := make([]string, 0)
+	groups := make(map[string][]*Node[T])
+	for _, node := range g.AllNodes() {
+		k := key(node)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], node)
+	}
+
+	collapsed := NewGraph[T]()
+	for _, k := range keys {
+		node := MakeNode(k, merge(k, groups[k]))
+		for _, n := range groups[k] {
+			node.AddErrors(n.Errors...)
+		}
+		collapsed.AddNode(node)
+	}
+
+	for _, k := range keys {
+		weights := make(map[string]int)
+		var deps []string
+		for _, n := range groups[k] {
+			for _, dep := range g.FromId(n.Id) {
+				depKey := key(dep)
+				if depKey == k {
+					continue
+				}
+				if _, ok := weights[depKey]; !ok {
+					deps = append(deps, depKey)
+				}
+				weights[depKey] += g.Weight(n.Id, dep.Id)
+			}
+		}
+		for _, dep := range deps {
+			// Both nodes were just added, so this cannot fail.
+			_ = collapsed.AddFromToEdge(k, dep)
+			_ = collapsed.SetWeight(k, dep, weights[dep])
+		}
+	}
+	return collapsed
+}
@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollapse(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{
+		0: {1, 2, 3},
+		1: {2, 4},
+		2: {4, 5},
+		3: {5},
+		4: {},
+		5: {},
+	})
+	g.Get("3").AddErrors(errors.New("foo"))
+	groups := map[int]string{0: "0", 1: "1", 2: "1", 3: "3", 4: "4", 5: "4"}
+	key := func(n *Node[int]) string { return groups[n.Data] }
+	collapsed := Collapse(g, key, func(_ string, nodes []*Node[int]) int {
+		sum := 0
+		for _, n := range nodes {
+			sum += n.Data
+		}
+		return sum
+	})
+
+	ids := func(nodes []*Node[int]) []string {
+		result := make([]string, len(nodes))
+		for i, n := range nodes {
+			result[i] = n.Id
+		}
+		return result
+	}
+	a.Equal([]string{"0", "1", "3", "4"}, ids(collapsed.AllNodes()))
+	a.Equal(3, collapsed.Get("1").Data)
+	a.Equal(9, collapsed.Get("4").Data)
+	a.Equal([]string{"1", "3"}, ids(collapsed.FromId("0")))
+	a.Equal([]string{"4"}, ids(collapsed.FromId("1")))
+	a.Equal(2, collapsed.Weight("0", "1"))
+	a.Equal(3, collapsed.Weight("1", "4"))
+	a.Equal(1, collapsed.Weight("3", "4"))
+	a.Len(collapsed.Get("3").Errors, 1)
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"slices"
+
+	"golang.org/x/exp/rand"
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/community"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// communitiesSeed makes the community detection return the same result on every run.
+const communitiesSeed = 1
+
+// undirected projects the graph into an undirected one, where the weight of each edge is the
+// amount of dependencies between both nodes.
+func (g *Graph[T]) undirected() (*simple.WeightedUndirectedGraph, bool) {
+	u := simple.NewWeightedUndirectedGraph(0, 0)
+	hasEdges := false
+	for _, node := range g.AllNodes() {
+		u.AddNode(simple.Node(node.ID()))
+	}
+	for _, node := range g.AllNodes() {
+		for _, dep := range g.FromId(node.Id) {
+			weight := 1.0
+			if edge := u.WeightedEdge(node.ID(), dep.ID()); edge != nil {
+				weight += edge.Weight()
+			}
+			u.SetWeightedEdge(u.NewWeightedEdge(simple.Node(node.ID()), simple.Node(dep.ID()), weight))
+			hasEdges = true
+		}
+	}
+	return u, hasEdges
+}
+
+// Communities splits the graph into groups of nodes that depend much more on each other than
+// on the rest of the nodes, using the Louvain method without taking into account the direction
+// of the dependencies. Communities are sorted by size, and the nodes in each one by ID.
+func (g *Graph[T]) Communities(resolution float64) [][]*Node[T] {
+	u, hasEdges := g.undirected()
+	var result [][]*Node[T]
+	if !hasEdges {
+		for _, node := range g.AllNodes() {
+			result = append(result, []*Node[T]{node})
+		}
+		return result
+	}
+	reduced := community.Modularize(u, resolution, rand.NewSource(communitiesSeed))
+	for _, c := range reduced.Communities() {
+		nodes := make([]*Node[T], len(c))
+		for i, n := range c {
+			nodes[i] = g.Node(n.ID()).(*Node[T])
+		}
+		slices.SortFunc(nodes, func(a, b *Node[T]) int { return int(a.ID() - b.ID()) })
+		result = append(result, nodes)
+	}
+	slices.SortStableFunc(result, func(a, b []*Node[T]) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return int(a[0].ID() - b[0].ID())
+	})
+	return result
+}
+
+// Modularity measures how much more the nodes in each community depend on each other than on
+// the rest of the nodes, without taking into account the direction of the dependencies. It goes
+// from -0.5 to 1, and it is 0 for a graph without dependencies.
+func (g *Graph[T]) Modularity(communities [][]*Node[T], resolution float64) float64 {
+	u, hasEdges := g.undirected()
+	if !hasEdges {
+		return 0
+	}
+	gonumCommunities := make([][]graph.Node, len(communities))
+	for i, c := range communities {
+		gonumCommunities[i] = make([]graph.Node, len(c))
+		for j, n := range c {
+			gonumCommunities[i][j] = simple.Node(n.ID())
+		}
+	}
+	return community.Q(u, gonumCommunities, resolution)
+}
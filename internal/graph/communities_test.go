@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func communityIds(communities [][]*Node[int]) [][]string {
+	result := make([][]string, len(communities))
+	for i, c := range communities {
+		for _, n := range c {
+			result[i] = append(result[i], n.Id)
+		}
+	}
+	return result
+}
+
+func TestGraph_Communities(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Children [][]int
+		Expected [][]string
+	}{
+		{
+			Name: "Two groups joined by a single dependency",
+			Children: [][]int{
+				0: {1, 2, 3},
+				1: {2, 3},
+				2: {3},
+				3: {4},
+				4: {5, 6, 7},
+				5: {6, 7},
+				6: {7},
+				7: {},
+			},
+			Expected: [][]string{{"0", "1", "2", "3"}, {"4", "5", "6", "7"}},
+		},
+		{
+			Name: "No dependencies",
+			Children: [][]int{
+				0: {},
+			},
+			Expected: [][]string{{"0"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			g := MakeTestGraph(tt.Children)
+			for i := 0; i < 5; i++ {
+				a.Equal(tt.Expected, communityIds(g.Communities(1)))
+			}
+		})
+	}
+}
+
+func TestGraph_Modularity(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{
+		0: {1, 2, 3},
+		1: {2, 3},
+		2: {3},
+		3: {4},
+		4: {5, 6, 7},
+		5: {6, 7},
+		6: {7},
+		7: {},
+	})
+	nodes := g.AllNodes()
+	good := g.Modularity([][]*Node[int]{nodes[:4], nodes[4:]}, 1)
+	bad := g.Modularity([][]*Node[int]{{nodes[0], nodes[4]}, {nodes[1], nodes[5]}, {nodes[2], nodes[6]}, {nodes[3], nodes[7]}}, 1)
+	a.Greater(good, 0.3)
+	a.Less(bad, 0.0)
+	a.Equal(g.Modularity(g.Communities(1), 1), good)
+
+	a.Equal(0.0, MakeTestGraph([][]int{0: {}}).Modularity([][]*Node[int]{nodes[:1]}, 1))
+}
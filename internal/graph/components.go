@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// Component is a strongly connected component of the graph that contains cycles: a set
+// of nodes where each one can reach all the others.
+type Component struct {
+	// Nodes are the ids of the nodes in the component, in the order they were added to the graph.
+	Nodes []string
+	// Cycles are some representative cycles within the component. Each cycle starts and ends
+	// with the same node.
+	Cycles [][]string
+}
+
+// CyclicComponents returns the strongly connected components of the graph that contain at
+// least one cycle, which are the ones with more than one node or with a node that depends
+// on itself. Up to maxCycles representative cycles are gathered for each component.
+func (g *Graph[T]) CyclicComponents(maxCycles int) []Component {
+	var result []Component
+	for _, scc := range topo.TarjanSCC(g) {
+		if len(scc) == 1 && !g.HasEdgeFromTo(scc[0].ID(), scc[0].ID()) {
+			continue
+		}
+		nodes := make([]*Node[T], len(scc))
+		for i, n := range scc {
+			nodes[i] = n.(*Node[T])
+		}
+		slices.SortFunc(nodes, func(a, b *Node[T]) int { return int(a.ID() - b.ID()) })
+
+		component := Component{Nodes: make([]string, len(nodes))}
+		for i, node := range nodes {
+			component.Nodes[i] = node.Id
+		}
+		if maxCycles > 0 {
+			component.Cycles = g.componentCycles(nodes, maxCycles)
+		}
+		result = append(result, component)
+	}
+	slices.SortFunc(result, func(a, b Component) int {
+		return int(g.Get(a.Nodes[0]).ID() - g.Get(b.Nodes[0]).ID())
+	})
+	return result
+}
+
+// componentCycles looks for the shortest cycle that goes through each edge in the component
+// until maxCycles different cycles are found.
+func (g *Graph[T]) componentCycles(nodes []*Node[T], maxCycles int) [][]string {
+	inComponent := make(map[int64]bool, len(nodes))
+	for _, node := range nodes {
+		inComponent[node.ID()] = true
+	}
+
+	var cycles [][]string
+	seen := map[string]bool{}
+	for _, from := range nodes {
+		for _, to := range g.FromId(from.Id) {
+			if !inComponent[to.ID()] {
+				continue
+			}
+			cycle := append([]string{from.Id}, g.shortestPath(to, from, inComponent)...)
+			key := cycleKey(cycle)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			cycles = append(cycles, cycle)
+			if len(cycles) == maxCycles {
+				return cycles
+			}
+		}
+	}
+	return cycles
+}
+
+// shortestPath returns the ids of the nodes in the shortest path from one node to another,
+// both included, only going through the allowed nodes, or through any node if allowed is nil.
+// As all nodes in a strongly connected component are reachable from each other, a path is
+// always found within the component.
+func (g *Graph[T]) shortestPath(from *Node[T], to *Node[T], allowed map[int64]bool) []string {
+	parents := map[int64]*Node[T]{from.ID(): nil}
+	queue := []*Node[T]{from}
+	for len(queue) > 0 && to.ID() != queue[0].ID() {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g.FromId(current.Id) {
+			if _, ok := parents[next.ID()]; ok || (allowed != nil && !allowed[next.ID()]) {
+				continue
+			}
+			parents[next.ID()] = current
+			queue = append(queue, next)
+		}
+	}
+	if _, ok := parents[to.ID()]; !ok {
+		return nil
+	}
+	var path []string
+	for node := to; node != nil; node = parents[node.ID()] {
+		path = append(path, node.Id)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// cycleKey identifies a cycle regardless of the node where it starts.
+func cycleKey(cycle []string) string {
+	nodes := cycle[:len(cycle)-1]
+	start := 0
+	for i, id := range nodes {
+		if id < nodes[start] {
+			start = i
+		}
+	}
+	return strings.Join(append(slices.Clone(nodes[start:]), nodes[:start]...), "\x00")
+}
@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestGraph_CyclicComponents(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Children  [][]int
+		MaxCycles int
+		Expected  []Component
+	}{
+		{
+			Name: "No cycles",
+			Children: [][]int{
+				0: {1, 2},
+				1: {2},
+				2: {},
+			},
+			MaxCycles: 5,
+		},
+		{
+			Name: "Two components",
+			Children: [][]int{
+				0: {1, 3},
+				1: {2},
+				2: {1},
+				3: {4},
+				4: {5},
+				5: {3},
+			},
+			MaxCycles: 5,
+			Expected: []Component{
+				{Nodes: []string{"1", "2"}, Cycles: [][]string{{"1", "2", "1"}}},
+				{Nodes: []string{"3", "4", "5"}, Cycles: [][]string{{"3", "4", "5", "3"}}},
+			},
+		},
+		{
+			Name: "Bounded cycles",
+			Children: [][]int{
+				0: {1, 2, 3},
+				1: {0, 2, 3},
+				2: {0, 1, 3},
+				3: {0, 1, 2},
+			},
+			MaxCycles: 2,
+			Expected: []Component{
+				{
+					Nodes:  []string{"0", "1", "2", "3"},
+					Cycles: [][]string{{"0", "1", "0"}, {"0", "2", "0"}},
+				},
+			},
+		},
+		{
+			Name: "No cycles requested",
+			Children: [][]int{
+				0: {1},
+				1: {0},
+			},
+			Expected: []Component{
+				{Nodes: []string{"0", "1"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			g := MakeTestGraph(tt.Children)
+			a.Equal(tt.Expected, g.CyclicComponents(tt.MaxCycles))
+		})
+	}
+}
+
+func TestGraph_CyclicComponents_SelfLoop(t *testing.T) {
+	a := require.New(t)
+	g := NewGraph[int]()
+	g.AddNode(MakeNode("0", 0))
+	g.AddNode(MakeNode("1", 1))
+	a.NoError(g.AddFromToEdge("0", "0", "1"))
+
+	a.Equal([]Component{{Nodes: []string{"0"}, Cycles: [][]string{{"0", "0"}}}}, g.CyclicComponents(5))
+}
+
+func TestGraph_RemoveElementaryCycles(t *testing.T) {
+	a := require.New(t)
+	// A complete graph has an enormous amount of elementary cycles.
+	children := make([][]int, 30)
+	for i := range children {
+		for j := range children {
+			if i != j {
+				children[i] = append(children[i], j)
+			}
+		}
+	}
+	g := MakeTestGraph(children)
+
+	cycles := g.RemoveElementaryCycles()
+	a.NotEmpty(cycles)
+	a.Empty(g.CyclicComponents(0))
+	_, err := topo.Sort(g)
+	a.NoError(err)
+	a.Equal(cycles, MakeTestGraph(children).RemoveElementaryCycles())
+}
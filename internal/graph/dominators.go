@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"gonum.org/v1/gonum/graph/flow"
+)
+
+// DominatorTree tells, for every node reachable from a root, which nodes can only be
+// reached from the root going through it.
+type DominatorTree[T any] struct {
+	g    *Graph[T]
+	tree flow.DominatorTree
+}
+
+// DominatorTree computes the dominator tree of the nodes reachable from root.
+func (g *Graph[T]) DominatorTree(root string) (*DominatorTree[T], error) {
+	node := g.Get(root)
+	if node == nil {
+		return nil, fmt.Errorf("'%s' is not in graph", root)
+	}
+	return &DominatorTree[T]{g: g, tree: flow.Dominators(node, g)}, nil
+}
+
+// Dominated returns all the nodes that can only be reached from the root going through
+// the provided node, sorted by ID. The node itself is not included.
+func (d *DominatorTree[T]) Dominated(id string) []*Node[T] {
+	node := d.g.Get(id)
+	if node == nil {
+		return nil
+	}
+	var result []*Node[T]
+	stack := []*Node[T]{node}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range d.tree.DominatedBy(current.ID()) {
+			result = append(result, child.(*Node[T]))
+			stack = append(stack, child.(*Node[T]))
+		}
+	}
+	slices.SortFunc(result, func(a, b *Node[T]) int { return int(a.ID() - b.ID()) })
+	return result
+}
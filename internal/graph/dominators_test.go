@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDominatorTree_Dominated(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Children [][]int
+		Root     string
+		Expected map[string][]string
+	}{
+		{
+			Name: "Chain",
+			Children: [][]int{
+				0: {1},
+				1: {2},
+				2: {},
+			},
+			Root: "0",
+			Expected: map[string][]string{
+				"0": {"1", "2"},
+				"1": {"2"},
+				"2": nil,
+			},
+		},
+		{
+			Name: "Diamond",
+			Children: [][]int{
+				0: {1, 2},
+				1: {3, 4},
+				2: {3},
+				3: {},
+				4: {},
+			},
+			Root: "0",
+			Expected: map[string][]string{
+				"0": {"1", "2", "3", "4"},
+				"1": {"4"},
+				"2": nil,
+				"3": nil,
+				"4": nil,
+			},
+		},
+		{
+			Name: "Cycles and unreachable nodes",
+			Children: [][]int{
+				0: {1},
+				1: {2},
+				2: {1, 3},
+				3: {},
+			},
+			Root: "1",
+			Expected: map[string][]string{
+				"0": nil,
+				"1": {"2", "3"},
+				"2": {"3"},
+				"3": nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			g := MakeTestGraph(tt.Children)
+			tree, err := g.DominatorTree(tt.Root)
+			a.NoError(err)
+
+			result := map[string][]string{}
+			for _, node := range g.AllNodes() {
+				var ids []string
+				for _, dominated := range tree.Dominated(node.Id) {
+					ids = append(ids, dominated.Id)
+				}
+				result[node.Id] = ids
+			}
+			a.Equal(tt.Expected, result)
+		})
+	}
+}
+
+func TestGraph_DominatorTree_UnknownRoot(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{0: {}})
+	_, err := g.DominatorTree("1")
+	a.EqualError(err, "'1' is not in graph")
+}
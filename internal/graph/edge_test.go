@@ -9,13 +9,16 @@ import (
 
 func TestEdge_ReversedEdge(t *testing.T) {
 	a := require.New(t)
+	g := NewGraph[int]()
+	g.AddNode(MakeNode("1", 1))
+	g.AddNode(MakeNode("2", 2))
 	var edge graph.Edge = &Edge[int]{
-		from: MakeNode("1", 1),
-		to:   MakeNode("2", 2),
+		from: g.Get("1"),
+		to:   g.Get("2"),
 	}
 
 	edge = edge.ReversedEdge()
 
-	a.Equal(edge.To().ID(), hash("1"))
-	a.Equal(edge.From().ID(), hash("2"))
+	a.Equal(edge.To().ID(), g.Get("1").ID())
+	a.Equal(edge.From().ID(), g.Get("2").ID())
 }
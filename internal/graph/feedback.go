@@ -0,0 +1,356 @@
+package graph
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// maxSiftingPasses bounds the local improvement of the ordering computed for the feedback arc set.
+const maxSiftingPasses = 20
+
+// FeedbackEdge is an edge that belongs to a feedback arc set.
+type FeedbackEdge struct {
+	From string
+	To   string
+}
+
+// FeedbackArcSet returns a small set of edges whose removal leaves the graph without cycles.
+// Finding the minimum set is NP-hard, so this is an approximation: each strongly connected
+// component is ordered with the Eades-Lin-Smyth heuristic, the order is improved moving nodes
+// one by one to the position where they produce the fewest backward edges, and the backward
+// edges that are not needed for breaking any cycle are discarded.
+func (g *Graph[T]) FeedbackArcSet() []FeedbackEdge {
+	var result []FeedbackEdge
+	for _, component := range g.CyclicComponents(0) {
+		result = append(result, g.componentFeedbackArcSet(component)...)
+	}
+	return result
+}
+
+// componentGraph is a compact representation of the edges within a strongly connected component.
+type componentGraph struct {
+	ids []string
+	out [][]int
+	in  [][]int
+}
+
+func (g *Graph[T]) newComponentGraph(component Component) *componentGraph {
+	cg := &componentGraph{
+		ids: component.Nodes,
+		out: make([][]int, len(component.Nodes)),
+		in:  make([][]int, len(component.Nodes)),
+	}
+	index := make(map[string]int, len(component.Nodes))
+	for i, id := range component.Nodes {
+		index[id] = i
+	}
+	for i, id := range component.Nodes {
+		for _, to := range g.FromId(id) {
+			if j, ok := index[to.Id]; ok {
+				cg.out[i] = append(cg.out[i], j)
+				cg.in[j] = append(cg.in[j], i)
+			}
+		}
+	}
+	return cg
+}
+
+func (g *Graph[T]) componentFeedbackArcSet(component Component) []FeedbackEdge {
+	cg := g.newComponentGraph(component)
+	order := cg.eadesOrder()
+	order = cg.sift(order)
+
+	pos := make([]int, len(order))
+	for i, v := range order {
+		pos[v] = i
+	}
+	var backward [][2]int
+	for u := range cg.out {
+		for _, v := range cg.out[u] {
+			if pos[v] <= pos[u] {
+				backward = append(backward, [2]int{u, v})
+			}
+		}
+	}
+
+	var result []FeedbackEdge
+	for _, e := range cg.minimize(backward) {
+		result = append(result, FeedbackEdge{From: cg.ids[e[0]], To: cg.ids[e[1]]})
+	}
+	return result
+}
+
+// deltaItem is an entry of the max-heap used for picking the node with the highest
+// outdegree - indegree in the Eades-Lin-Smyth heuristic.
+type deltaItem struct {
+	delta int
+	node  int
+}
+
+type deltaHeap []deltaItem
+
+func (h deltaHeap) Len() int { return len(h) }
+func (h deltaHeap) Less(i, j int) bool {
+	if h[i].delta != h[j].delta {
+		return h[i].delta > h[j].delta
+	}
+	return h[i].node < h[j].node
+}
+func (h deltaHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *deltaHeap) Push(x any)   { *h = append(*h, x.(deltaItem)) }
+func (h *deltaHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// eadesOrder orders the nodes so that few edges go backwards: sinks are repeatedly placed
+// at the end, sources at the beginning, and if there are none, the node with most outgoing
+// and fewest incoming edges is placed at the beginning.
+func (cg *componentGraph) eadesOrder() []int {
+	n := len(cg.ids)
+	outDeg, inDeg := make([]int, n), make([]int, n)
+	removed := make([]bool, n)
+	h := &deltaHeap{}
+	var sinks, sources []int
+	for v := 0; v < n; v++ {
+		for _, w := range cg.out[v] {
+			if w != v {
+				outDeg[v]++
+				inDeg[w]++
+			}
+		}
+	}
+	for v := 0; v < n; v++ {
+		heap.Push(h, deltaItem{outDeg[v] - inDeg[v], v})
+		if outDeg[v] == 0 {
+			sinks = append(sinks, v)
+		} else if inDeg[v] == 0 {
+			sources = append(sources, v)
+		}
+	}
+
+	var left, right []int
+	remove := func(v int) {
+		removed[v] = true
+		for _, w := range cg.out[v] {
+			if !removed[w] {
+				inDeg[w]--
+				heap.Push(h, deltaItem{outDeg[w] - inDeg[w], w})
+				if inDeg[w] == 0 {
+					sources = append(sources, w)
+				}
+			}
+		}
+		for _, w := range cg.in[v] {
+			if !removed[w] {
+				outDeg[w]--
+				heap.Push(h, deltaItem{outDeg[w] - inDeg[w], w})
+				if outDeg[w] == 0 {
+					sinks = append(sinks, w)
+				}
+			}
+		}
+	}
+
+	for len(left)+len(right) < n {
+		switch {
+		case len(sinks) > 0:
+			v := sinks[0]
+			sinks = sinks[1:]
+			if !removed[v] {
+				remove(v)
+				right = append(right, v)
+			}
+		case len(sources) > 0:
+			v := sources[0]
+			sources = sources[1:]
+			if !removed[v] {
+				remove(v)
+				left = append(left, v)
+			}
+		default:
+			item := heap.Pop(h).(deltaItem)
+			// The heap might hold outdated entries, those are skipped.
+			if !removed[item.node] && item.delta == outDeg[item.node]-inDeg[item.node] {
+				remove(item.node)
+				left = append(left, item.node)
+			}
+		}
+	}
+	slices.Reverse(right)
+	return append(left, right...)
+}
+
+// sift moves each node to the position in the order where it has the fewest backward
+// edges, until no node can be moved for reducing them.
+func (cg *componentGraph) sift(order []int) []int {
+	n := len(order)
+	pos := make([]int, n)
+	for i, v := range order {
+		pos[v] = i
+	}
+	type event struct {
+		at    int
+		delta int
+	}
+	for pass := 0; pass < maxSiftingPasses; pass++ {
+		improved := false
+		for v := 0; v < n; v++ {
+			// Positions are computed as if v was not in the order, placing v at slot p means
+			// that it goes right before the node that is at position p in that order.
+			others := func(w int) int {
+				if pos[w] > pos[v] {
+					return pos[w] - 1
+				}
+				return pos[w]
+			}
+			var events []event
+			cost := 0
+			for _, w := range cg.out[v] {
+				if w != v {
+					// An outgoing edge goes backwards once v is placed after w.
+					events = append(events, event{others(w) + 1, 1})
+				}
+			}
+			for _, w := range cg.in[v] {
+				if w != v {
+					// An incoming edge goes backwards while v is placed before w.
+					cost++
+					events = append(events, event{others(w) + 1, -1})
+				}
+			}
+			slices.SortFunc(events, func(a, b event) int { return a.at - b.at })
+
+			// The cost only changes at the slots where events happen.
+			current := pos[v]
+			currentCost, bestCost, best := cost, cost, 0
+			for i, e := range events {
+				cost += e.delta
+				if e.at <= current {
+					currentCost = cost
+				}
+				if (i == len(events)-1 || events[i+1].at != e.at) && cost < bestCost {
+					bestCost, best = cost, e.at
+				}
+			}
+			if bestCost >= currentCost {
+				continue
+			}
+			improved = true
+			order = slices.Delete(order, current, current+1)
+			order = slices.Insert(order, best, v)
+			for i, w := range order {
+				pos[w] = i
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return order
+}
+
+// minimize returns the edges of the candidates that are needed for the rest of the graph
+// to be acyclic: each candidate is added back to the graph if it does not close a cycle.
+func (cg *componentGraph) minimize(candidates [][2]int) [][2]int {
+	removed := make(map[[2]int]bool, len(candidates))
+	for _, e := range candidates {
+		removed[e] = true
+	}
+	reaches := func(from, to int) bool {
+		visited := make([]bool, len(cg.ids))
+		visited[from] = true
+		queue := []int{from}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if current == to {
+				return true
+			}
+			for _, next := range cg.out[current] {
+				if !visited[next] && !removed[[2]int{current, next}] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		return false
+	}
+
+	var result [][2]int
+	for _, e := range candidates {
+		if e[0] != e[1] && !reaches(e[1], e[0]) {
+			delete(removed, e)
+		} else {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// CycleCount is the amount of elementary cycles that go through an edge.
+type CycleCount struct {
+	Count int
+	// Exact is false if counting stopped at the limit, in which case Count is a lower bound.
+	Exact bool
+}
+
+// CountCyclesThrough counts, for each one of the edges, the elementary cycles that go through
+// it, which are the simple paths that go back from To -> From. The amount of cycles can grow
+// exponentially, so counting stops at limit.
+func (g *Graph[T]) CountCyclesThrough(edges []FeedbackEdge, limit int) []CycleCount {
+	// Paths that go out of the strongly connected component never make it back.
+	componentOf := map[string]map[string]bool{}
+	for _, component := range g.CyclicComponents(0) {
+		nodes := map[string]bool{}
+		for _, id := range component.Nodes {
+			nodes[id] = true
+			componentOf[id] = nodes
+		}
+	}
+	result := make([]CycleCount, len(edges))
+	for i, edge := range edges {
+		result[i] = g.countCyclesThrough(edge.From, edge.To, limit, componentOf[edge.From])
+	}
+	return result
+}
+
+// countCyclesThrough counts the simple paths from to -> from that only go through allowed nodes.
+func (g *Graph[T]) countCyclesThrough(from string, to string, limit int, allowed map[string]bool) CycleCount {
+	if from == to {
+		return CycleCount{Count: 1, Exact: true}
+	}
+	if g.Get(to) == nil || g.Get(from) == nil {
+		return CycleCount{Exact: true}
+	}
+
+	count := 0
+	// The amount of visited nodes is also bounded, as there might be an exponential amount
+	// of paths that do not lead anywhere.
+	budget := 100 * limit
+	truncated := false
+	onPath := map[string]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		if count >= limit || budget <= 0 {
+			truncated = true
+			return
+		}
+		budget--
+		if id == from {
+			count++
+			return
+		}
+		onPath[id] = true
+		for _, next := range g.FromId(id) {
+			if allowed[next.Id] && !onPath[next.Id] {
+				visit(next.Id)
+			}
+		}
+		delete(onPath, id)
+	}
+	visit(to)
+	return CycleCount{Count: count, Exact: !truncated}
+}
@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+func TestGraph_FeedbackArcSet(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Children [][]int
+		Expected []FeedbackEdge
+	}{
+		{
+			Name: "No cycles",
+			Children: [][]int{
+				0: {1, 2},
+				1: {2},
+				2: {},
+			},
+		},
+		{
+			Name: "Simple cycle",
+			Children: [][]int{
+				0: {1},
+				1: {2},
+				2: {0},
+			},
+			Expected: []FeedbackEdge{{"2", "0"}},
+		},
+		{
+			Name: "One edge in all the cycles",
+			Children: [][]int{
+				0: {1},
+				1: {2, 3, 4},
+				2: {0},
+				3: {0},
+				4: {0},
+			},
+			Expected: []FeedbackEdge{{"0", "1"}},
+		},
+		{
+			Name: "Two components",
+			Children: [][]int{
+				0: {1, 3},
+				1: {2},
+				2: {1},
+				3: {4},
+				4: {3},
+			},
+			Expected: []FeedbackEdge{{"2", "1"}, {"4", "3"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			g := MakeTestGraph(tt.Children)
+			fas := g.FeedbackArcSet()
+			a.Equal(tt.Expected, fas)
+
+			for _, edge := range fas {
+				g.RemoveFromToEdge(edge.From, edge.To)
+			}
+			_, err := topo.Sort(g)
+			a.NoError(err)
+		})
+	}
+}
+
+func TestGraph_FeedbackArcSet_Complete(t *testing.T) {
+	a := require.New(t)
+	// In a graph where every node depends on every other, half of the edges need to go.
+	n := 20
+	children := make([][]int, n)
+	for i := range children {
+		for j := range children {
+			if i != j {
+				children[i] = append(children[i], j)
+			}
+		}
+	}
+	g := MakeTestGraph(children)
+	fas := g.FeedbackArcSet()
+	a.Len(fas, n*(n-1)/2)
+
+	for _, edge := range fas {
+		g.RemoveFromToEdge(edge.From, edge.To)
+	}
+	_, err := topo.Sort(g)
+	a.NoError(err)
+}
+
+func TestGraph_CountCyclesThrough(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{
+		0: {1},
+		1: {2, 3, 4},
+		2: {0, 3},
+		3: {0},
+		4: {0},
+		5: {},
+	})
+
+	counts := g.CountCyclesThrough([]FeedbackEdge{{"0", "1"}, {"2", "3"}, {"4", "5"}}, 10)
+	a.Equal([]CycleCount{{Count: 4, Exact: true}, {Count: 1, Exact: true}, {Count: 0, Exact: true}}, counts)
+
+	counts = g.CountCyclesThrough([]FeedbackEdge{{"0", "1"}}, 2)
+	a.Equal([]CycleCount{{Count: 2, Exact: false}}, counts)
+}
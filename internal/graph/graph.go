@@ -8,6 +8,9 @@ import (
 )
 
 type Graph[T any] struct {
+	// ids interns the string ids of the nodes into numeric ids, that are assigned
+	// incrementally in the order in which nodes are added.
+	ids   map[string]int64
 	nodes *om.OrderedMap[int64, *Node[T]]
 	// Here "from" means: from node X I can reach nodes A, B and C
 	// file -> dep
@@ -15,6 +18,11 @@ type Graph[T any] struct {
 	// Here "to" means: node X can be reached by A, B and C
 	// dep -> file
 	toEdges *om.OrderedMap[int64, *om.OrderedMap[int64, bool]]
+	// weights holds the weight of the edges that do not have the default weight of 1.
+	weights map[[2]int64]int
+	// data holds whatever the edges that have some payload attached carry, like the
+	// imported symbols.
+	data map[[2]int64]any
 }
 
 var _ graph.Directed = &Graph[any]{}
@@ -79,29 +87,50 @@ func (g *Graph[T]) To(id int64) graph.Nodes {
 
 func NewGraph[T any]() *Graph[T] {
 	return &Graph[T]{
+		ids:       make(map[string]int64),
 		nodes:     om.NewOrderedMap[int64, *Node[T]](),
 		fromEdges: om.NewOrderedMap[int64, *om.OrderedMap[int64, bool]](),
 		toEdges:   om.NewOrderedMap[int64, *om.OrderedMap[int64, bool]](),
+		weights:   make(map[[2]int64]int),
+		data:      make(map[[2]int64]any),
 	}
 }
 
+// numericId returns the numeric id interned for the string id, or -1 if no node
+// with that id was ever added to the graph.
+func (g *Graph[T]) numericId(id string) int64 {
+	if numeric, ok := g.ids[id]; ok {
+		return numeric
+	}
+	return -1
+}
+
 func (g *Graph[T]) Has(nodeId string) bool {
-	_, ok := g.nodes.Get(hashCached(nodeId))
+	_, ok := g.nodes.Get(g.numericId(nodeId))
 	return ok
 }
 
+// AddNode adds the node to the graph, assigning it a numeric id. Adding a node with the
+// same string id as a previous one replaces it and reuses its numeric id. A node can only
+// belong to one graph at a time.
 func (g *Graph[T]) AddNode(node *Node[T]) {
-	g.nodes.Set(node.ID(), node)
+	id, ok := g.ids[node.Id]
+	if !ok {
+		id = int64(len(g.ids))
+		g.ids[node.Id] = id
+	}
+	node.id = id
+	g.nodes.Set(id, node)
 }
 
 func (g *Graph[T]) AddFromToEdge(fromId string, toIds ...string) error {
-	from := hashCached(fromId)
+	from := g.numericId(fromId)
 	if _, ok := g.nodes.Get(from); !ok {
 		return fmt.Errorf("'%s' is not in graph", fromId)
 	}
 
 	for _, toId := range toIds {
-		to := hashCached(toId)
+		to := g.numericId(toId)
 		if _, ok := g.nodes.Get(to); !ok {
 			return fmt.Errorf("'%s' is not in graph", toId)
 		}
@@ -124,14 +153,74 @@ func (g *Graph[T]) AddFromToEdge(fromId string, toIds ...string) error {
 }
 
 func (g *Graph[T]) RemoveFromToEdge(fromId string, toId string) {
-	from := hashCached(fromId)
-	to := hashCached(toId)
+	from := g.numericId(fromId)
+	to := g.numericId(toId)
 	if toNodes, ok := g.fromEdges.Get(from); ok {
 		toNodes.Delete(to)
 	}
 	if fromNodes, ok := g.toEdges.Get(to); ok {
 		fromNodes.Delete(from)
 	}
+	delete(g.weights, [2]int64{from, to})
+	delete(g.data, [2]int64{from, to})
+}
+
+// SetWeight sets how much the edge from fromId to toId weighs, for example, how many
+// imports it represents. The edge must already exist.
+func (g *Graph[T]) SetWeight(fromId string, toId string, weight int) error {
+	from := g.numericId(fromId)
+	to := g.numericId(toId)
+	if !g.HasEdgeFromTo(from, to) {
+		return fmt.Errorf("there is no edge from '%s' to '%s'", fromId, toId)
+	}
+	if weight == 1 {
+		delete(g.weights, [2]int64{from, to})
+	} else {
+		g.weights[[2]int64{from, to}] = weight
+	}
+	return nil
+}
+
+// Weight returns the weight of the edge from fromId to toId, which is 1 unless it was
+// set otherwise, or 0 if there is no such edge.
+func (g *Graph[T]) Weight(fromId string, toId string) int {
+	from := g.numericId(fromId)
+	to := g.numericId(toId)
+	if !g.HasEdgeFromTo(from, to) {
+		return 0
+	}
+	if weight, ok := g.weights[[2]int64{from, to}]; ok {
+		return weight
+	}
+	return 1
+}
+
+// SetEdgeData attaches a payload to the edge from fromId to toId, replacing the previous
+// one. The edge must already exist.
+func (g *Graph[T]) SetEdgeData(fromId string, toId string, data any) error {
+	from := g.numericId(fromId)
+	to := g.numericId(toId)
+	if !g.HasEdgeFromTo(from, to) {
+		return fmt.Errorf("there is no edge from '%s' to '%s'", fromId, toId)
+	}
+	if data == nil {
+		delete(g.data, [2]int64{from, to})
+	} else {
+		g.data[[2]int64{from, to}] = data
+	}
+	return nil
+}
+
+// EdgeData returns the payload attached to the edge from fromId to toId, or nil if
+// there is none.
+func (g *Graph[T]) EdgeData(fromId string, toId string) any {
+	return g.data[[2]int64{g.numericId(fromId), g.numericId(toId)}]
+}
+
+// LineData is implemented by the payload of the edges that know where the dependency is
+// imported in the source file.
+type LineData interface {
+	Line() int
 }
 
 func (g *Graph[T]) AllNodes() []*Node[T] {
@@ -144,17 +233,17 @@ func (g *Graph[T]) AllNodes() []*Node[T] {
 }
 
 func (g *Graph[T]) Get(id string) *Node[T] {
-	node, _ := g.nodes.Get(hashCached(id))
+	node, _ := g.nodes.Get(g.numericId(id))
 	return node
 }
 
 // FromId returns the nodes to which id can reach.
 func (g *Graph[T]) FromId(id string) []*Node[T] {
-	return g.from(hashCached(id))
+	return g.from(g.numericId(id))
 }
 
-func (g *Graph[T]) from(idHash int64) []*Node[T] {
-	if toNodes, ok := g.fromEdges.Get(idHash); ok {
+func (g *Graph[T]) from(id int64) []*Node[T] {
+	if toNodes, ok := g.fromEdges.Get(id); ok {
 		result := make([]*Node[T], toNodes.Len())
 		for i, to := range toNodes.Keys() {
 			if toNode, ok := g.nodes.Get(to); ok {
@@ -169,11 +258,11 @@ func (g *Graph[T]) from(idHash int64) []*Node[T] {
 
 // ToId returns the nodes from which id is reachable.
 func (g *Graph[T]) ToId(id string) []*Node[T] {
-	return g.to(hashCached(id))
+	return g.to(g.numericId(id))
 }
 
-func (g *Graph[T]) to(idHash int64) []*Node[T] {
-	if fromNodes, ok := g.toEdges.Get(idHash); ok {
+func (g *Graph[T]) to(id int64) []*Node[T] {
+	if fromNodes, ok := g.toEdges.Get(id); ok {
 		result := make([]*Node[T], fromNodes.Len())
 		for i, from := range fromNodes.Keys() {
 			if fromNode, ok := g.nodes.Get(from); ok {
@@ -18,8 +18,10 @@ func TestGraph_AddNode(t *testing.T) {
 	a.Equal(true, g.Has("1"))
 	a.Equal(false, g.Has("2"))
 
-	a.Equal(g.Get("0"), MakeNode[int]("0", 0))
-	a.Equal(g.Get("1"), MakeNode[int]("1", 1))
+	a.Equal("0", g.Get("0").Id)
+	a.Equal(0, g.Get("0").Data)
+	a.Equal("1", g.Get("1").Id)
+	a.Equal(1, g.Get("1").Data)
 	a.Nil(g.Get("2"))
 
 	a.Equal(true, g.Has("0"))
@@ -98,3 +100,41 @@ func TestGraph_GetNodesWithoutParents(t *testing.T) {
 	nodes = g.GetNodesWithoutParents()
 	a.Equal(0, len(nodes))
 }
+
+func TestGraph_Weight(t *testing.T) {
+	a := require.New(t)
+	g := NewGraph[int]()
+	g.AddNode(MakeNode[int]("0", 0))
+	g.AddNode(MakeNode[int]("1", 1))
+	a.NoError(g.AddFromToEdge("0", "1"))
+
+	a.Equal(1, g.Weight("0", "1"))
+	a.Equal(0, g.Weight("1", "0"))
+	a.NoError(g.SetWeight("0", "1", 3))
+	a.Equal(3, g.Weight("0", "1"))
+	a.Error(g.SetWeight("1", "0", 3))
+
+	g.RemoveFromToEdge("0", "1")
+	a.Equal(0, g.Weight("0", "1"))
+	a.NoError(g.AddFromToEdge("0", "1"))
+	a.Equal(1, g.Weight("0", "1"))
+}
+
+func TestGraph_EdgeData(t *testing.T) {
+	a := require.New(t)
+	g := NewGraph[int]()
+	g.AddNode(MakeNode[int]("0", 0))
+	g.AddNode(MakeNode[int]("1", 1))
+	a.NoError(g.AddFromToEdge("0", "1"))
+
+	a.Nil(g.EdgeData("0", "1"))
+	a.NoError(g.SetEdgeData("0", "1", "foo"))
+	a.Equal("foo", g.EdgeData("0", "1"))
+	a.Nil(g.EdgeData("1", "0"))
+	a.Error(g.SetEdgeData("1", "0", "foo"))
+
+	g.RemoveFromToEdge("0", "1")
+	a.Nil(g.EdgeData("0", "1"))
+	a.NoError(g.AddFromToEdge("0", "1"))
+	a.Nil(g.EdgeData("0", "1"))
+}
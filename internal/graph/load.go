@@ -3,8 +3,8 @@ package graph
 import (
 	"fmt"
 	"os"
+	"sync"
 
-	"github.com/gammazero/deque"
 	"github.com/schollz/progressbar/v3"
 )
 
@@ -13,12 +13,79 @@ type NodeParser[T any] interface {
 	Deps(node *Node[T]) ([]*Node[T], error)
 }
 
+// ParallelNodeParser is a NodeParser that is safe for concurrent use. Parallelism is
+// the maximum amount of goroutines that can call its methods at the same time.
+type ParallelNodeParser[T any] interface {
+	NodeParser[T]
+	Parallelism() int
+}
+
+// WeightedNodeParser is a NodeParser that also knows how much the dependency between
+// two nodes weighs, so that the loaded graph carries weighted edges.
+type WeightedNodeParser[T any] interface {
+	NodeParser[T]
+	Weight(from string, to string) int
+}
+
+// EdgeDataNodeParser is a NodeParser that also knows some payload about the dependency
+// between two nodes, like the symbols imported, so that the loaded graph carries it in
+// its edges.
+type EdgeDataNodeParser[T any] interface {
+	NodeParser[T]
+	EdgeData(from string, to string) any
+}
+
 type NodeParserBuilder[T any] func([]string) (NodeParser[T], error)
 
+type depsResult[T any] struct {
+	deps []*Node[T]
+	err  error
+}
+
+// loadDeps calls parser.Deps for every node using at most jobs goroutines. Results are
+// returned in the same order as nodes.
+func loadDeps[T any](nodes []*Node[T], parser NodeParser[T], jobs int) []depsResult[T] {
+	results := make([]depsResult[T], len(nodes))
+	if jobs <= 1 || len(nodes) <= 1 {
+		for i, node := range nodes {
+			results[i].deps, results[i].err = parser.Deps(node)
+		}
+		return results
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(jobs, len(nodes)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].deps, results[i].err = parser.Deps(nodes[i])
+			}
+		}()
+	}
+	for i := range nodes {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// Load builds the graph performing a breadth first search starting from ids. If the parser
+// is a ParallelNodeParser, the dependencies of all the nodes in the same level of the search
+// are loaded in parallel, but they are always inserted in the graph in the same order as in
+// a sequential search, so the result does not depend on scheduling.
 func (g *Graph[T]) Load(ids []string, parser NodeParser[T], callbacks LoadCallbacks[T]) error {
 	if callbacks == nil {
 		callbacks = &EmptyCallbacks[T]{}
 	}
+	jobs := 1
+	if parallelParser, ok := parser.(ParallelNodeParser[T]); ok {
+		jobs = parallelParser.Parallelism()
+	}
+	weightedParser, weighted := parser.(WeightedNodeParser[T])
+	dataParser, withData := parser.(EdgeDataNodeParser[T])
 	visited := make(map[string]bool)
 	callbacks.onStartLoading(ids)
 
@@ -30,37 +97,54 @@ func (g *Graph[T]) Load(ids []string, parser NodeParser[T], callbacks LoadCallba
 		if node == nil {
 			continue
 		}
-		var queue deque.Deque[*Node[T]]
-		queue.PushBack(node)
 		if !g.Has(node.Id) {
 			g.AddNode(node)
 		}
-		for queue.Len() > 0 {
-			node := queue.PopFront()
-			if _, ok := visited[node.Id]; ok {
-				continue
-			}
-			visited[node.Id] = true
-
-			deps, err := parser.Deps(node)
-			if err != nil {
-				node.AddErrors(err)
-				continue
+		level := []*Node[T]{node}
+		for len(level) > 0 {
+			var toLoad []*Node[T]
+			for _, node := range level {
+				if _, ok := visited[node.Id]; ok {
+					continue
+				}
+				visited[node.Id] = true
+				toLoad = append(toLoad, node)
 			}
-			callbacks.onNodeLoaded(node, deps)
 
-			for _, dep := range deps {
-				// No own child.
-				if dep.Id == node.Id {
+			level = nil
+			for i, result := range loadDeps(toLoad, parser, jobs) {
+				node := toLoad[i]
+				if result.err != nil {
+					node.AddErrors(result.err)
 					continue
 				}
-				if !g.Has(dep.Id) {
-					g.AddNode(dep)
-				}
-				err = g.AddFromToEdge(node.Id, dep.Id)
-				queue.PushBack(dep)
-				if err != nil {
-					return err
+				callbacks.onNodeLoaded(node, result.deps)
+
+				for _, dep := range result.deps {
+					// No own child.
+					if dep.Id == node.Id {
+						continue
+					}
+					if !g.Has(dep.Id) {
+						g.AddNode(dep)
+					}
+					err = g.AddFromToEdge(node.Id, dep.Id)
+					level = append(level, dep)
+					if err != nil {
+						return err
+					}
+					if weighted {
+						err = g.SetWeight(node.Id, dep.Id, weightedParser.Weight(node.Id, dep.Id))
+						if err != nil {
+							return err
+						}
+					}
+					if withData {
+						err = g.SetEdgeData(node.Id, dep.Id, dataParser.EdgeData(node.Id, dep.Id))
+						if err != nil {
+							return err
+						}
+					}
 				}
 			}
 		}
@@ -199,3 +199,42 @@ func TestLoadDeps_loadGraph(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadDeps_ParallelIsDeterministic(t *testing.T) {
+	a := require.New(t)
+
+	// Every node depends on a few others, with plenty of cycles and shared children.
+	spec := make([][]int, 200)
+	for i := range spec {
+		for _, k := range []int{1, 7, 31} {
+			spec[i] = append(spec[i], (i*k+k)%len(spec))
+		}
+	}
+	spec[13] = append(spec[13], -1)
+
+	load := func(jobs int) *Graph[[]int] {
+		g := NewGraph[[]int]()
+		err := g.Load([]string{"0", "150"}, &TestParser{Spec: spec, Jobs: jobs}, nil)
+		a.NoError(err)
+		return g
+	}
+	render := func(g *Graph[[]int]) []string {
+		var result []string
+		for _, node := range g.AllNodes() {
+			line := node.Id + " ->"
+			for _, dep := range g.FromId(node.Id) {
+				line += " " + dep.Id
+			}
+			for _, err := range node.Errors {
+				line += " error: " + err.Error()
+			}
+			result = append(result, line)
+		}
+		return result
+	}
+
+	expected := render(load(1))
+	for i := 0; i < 10; i++ {
+		a.Equal(expected, render(load(8)))
+	}
+}
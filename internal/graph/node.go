@@ -1,11 +1,7 @@
 package graph
 
 import (
-	"hash/fnv"
-
 	"gonum.org/v1/gonum/graph"
-
-	"github.com/gabotechs/dep-tree/internal/utils"
 )
 
 type Node[T any] struct {
@@ -18,21 +14,16 @@ type Node[T any] struct {
 	// Data is a generic implementation-defined data bucket. Implementations can put
 	//  whatever they want here.
 	Data T
+	// id is the numeric identity of the node, assigned by the graph it is added to.
+	id int64
 }
 
-func hash(s string) int64 {
-	h := fnv.New32a()
-	_, _ = h.Write([]byte(s))
-	return int64(h.Sum32())
-}
-
-var hashCached = utils.Cached1In1Out(hash)
-
 func MakeNode[T any](id string, data T) *Node[T] {
 	return &Node[T]{
 		Id:     id,
 		Errors: make([]error, 0),
 		Data:   data,
+		id:     -1,
 	}
 }
 
@@ -40,8 +31,10 @@ func (n *Node[T]) AddErrors(err ...error) {
 	n.Errors = append(n.Errors, err...)
 }
 
+// ID returns the numeric identity of the node, which is unique within the graph it
+// belongs to. Nodes that were not added to any graph yet have an ID of -1.
 func (n *Node[T]) ID() int64 {
-	return hashCached(n.Id)
+	return n.id
 }
 
 type Nodes[T any] struct {
@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,41 +9,62 @@ import (
 
 func TestNewNodesIterator(t *testing.T) {
 	a := require.New(t)
-	iterator := NewNodesIterator([]*Node[int]{
-		MakeNode("1", 1),
-		MakeNode("2", 2),
-		MakeNode("3", 3),
-		MakeNode("4", 4),
-	})
+	g := NewGraph[int]()
+	for i := 1; i <= 4; i++ {
+		g.AddNode(MakeNode(strconv.Itoa(i), i))
+	}
+	iterator := NewNodesIterator(g.AllNodes())
 
 	a.Nil(iterator.Node())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("1"))
+	a.Equal(iterator.Node().ID(), g.Get("1").ID())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("2"))
+	a.Equal(iterator.Node().ID(), g.Get("2").ID())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("3"))
+	a.Equal(iterator.Node().ID(), g.Get("3").ID())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("4"))
+	a.Equal(iterator.Node().ID(), g.Get("4").ID())
 	a.Equal(iterator.Next(), false)
 	a.Nil(iterator.Node())
 	iterator.Reset()
 	a.Nil(iterator.Node())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("1"))
+	a.Equal(iterator.Node().ID(), g.Get("1").ID())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("2"))
+	a.Equal(iterator.Node().ID(), g.Get("2").ID())
 	a.Equal(iterator.Next(), true)
 	iterator.Reset()
 	a.Nil(iterator.Node())
 	a.Equal(iterator.Next(), true)
-	a.Equal(iterator.Node().ID(), hashCached("1"))
+	a.Equal(iterator.Node().ID(), g.Get("1").ID())
 	a.Equal(iterator.Next(), true)
 }
 
-func TestHashCached(t *testing.T) {
+func TestNode_ID(t *testing.T) {
 	a := require.New(t)
-	a.Equal(hashCached("foo"), hash("foo"))
-	a.Equal(hashCached("foo"), hashCached("foo"))
-	a.Equal(MakeNode("foo", 1).ID(), hashCached("foo"))
+	g := NewGraph[int]()
+	a.Equal(int64(-1), MakeNode("foo", 1).ID())
+
+	g.AddNode(MakeNode("foo", 1))
+	g.AddNode(MakeNode("bar", 2))
+	a.Equal(int64(0), g.Get("foo").ID())
+	a.Equal(int64(1), g.Get("bar").ID())
+
+	// Re-adding a node keeps its id.
+	g.AddNode(MakeNode("foo", 3))
+	a.Equal(int64(0), g.Get("foo").ID())
+	a.Equal(3, g.Get("foo").Data)
+	a.Equal(2, len(g.AllNodes()))
+}
+
+func TestNode_IDIsCollisionFree(t *testing.T) {
+	a := require.New(t)
+	g := NewGraph[int]()
+	// These two strings have the same 32-bit FNV-1a hash.
+	g.AddNode(MakeNode("costarring", 1))
+	g.AddNode(MakeNode("liquid", 2))
+	a.NotEqual(g.Get("costarring").ID(), g.Get("liquid").ID())
+	a.NoError(g.AddFromToEdge("costarring", "liquid"))
+	a.Equal([]*Node[int]{g.Get("liquid")}, g.FromId("costarring"))
+	a.Equal(0, len(g.FromId("liquid")))
 }
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"slices"
+)
+
+// ShortestPath returns the ids of the nodes in the shortest path from one node to another,
+// both included, or nil if the latter is not reachable from the former.
+func (g *Graph[T]) ShortestPath(from string, to string) []string {
+	start, end := g.Get(from), g.Get(to)
+	if start == nil || end == nil {
+		return nil
+	}
+	return g.shortestPath(start, end, nil)
+}
+
+// SimplePaths returns the paths from one node to another that do not go through the same node
+// twice, shortest first. The amount of paths can grow exponentially, so the search stops at
+// limit, in which case the second return value is false.
+func (g *Graph[T]) SimplePaths(from string, to string, limit int) ([][]string, bool) {
+	start, end := g.Get(from), g.Get(to)
+	if start == nil || end == nil {
+		return nil, true
+	}
+	// Only the nodes from which the destination can be reached are worth visiting, and the
+	// distance to the destination tells whether a path can still be completed in time.
+	distance := map[string]int{to: 0}
+	queue := []*Node[T]{end}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, prev := range g.ToId(current.Id) {
+			if _, ok := distance[prev.Id]; !ok {
+				distance[prev.Id] = distance[current.Id] + 1
+				queue = append(queue, prev)
+			}
+		}
+	}
+	if _, ok := distance[from]; !ok {
+		return nil, true
+	}
+
+	var result [][]string
+	truncated := false
+	var path []string
+	onPath := map[string]bool{}
+	// Paths are searched by increasing length, so that the shortest ones are the ones kept
+	// when the search stops at limit.
+	for length := distance[from]; length < len(distance) && !truncated; length++ {
+		// The amount of visited nodes is also bounded, as there might be an exponential
+		// amount of paths that do not lead anywhere.
+		budget := 100 * limit
+		var visit func(id string)
+		visit = func(id string) {
+			if truncated {
+				return
+			} else if budget <= 0 {
+				truncated = true
+				return
+			}
+			budget--
+			path = append(path, id)
+			if id == to {
+				// Shorter paths were already found in previous iterations.
+				if len(path)-1 == length && len(result) >= limit {
+					truncated = true
+				} else if len(path)-1 == length {
+					result = append(result, slices.Clone(path))
+				}
+			} else {
+				onPath[id] = true
+				for _, next := range g.FromId(id) {
+					d, ok := distance[next.Id]
+					if ok && !onPath[next.Id] && len(path)+d <= length {
+						visit(next.Id)
+					}
+				}
+				delete(onPath, id)
+			}
+			path = path[:len(path)-1]
+		}
+		visit(from)
+	}
+	return result, !truncated
+}
@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_ShortestPath(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{
+		0: {1, 2},
+		1: {3},
+		2: {4},
+		3: {4},
+		4: {},
+	})
+
+	a.Equal([]string{"0", "2", "4"}, g.ShortestPath("0", "4"))
+	a.Equal([]string{"1", "3", "4"}, g.ShortestPath("1", "4"))
+	a.Equal([]string{"3"}, g.ShortestPath("3", "3"))
+	a.Nil(g.ShortestPath("4", "0"))
+	a.Nil(g.ShortestPath("0", "5"))
+}
+
+func TestGraph_SimplePaths(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Children [][]int
+		From     string
+		To       string
+		Limit    int
+		Expected [][]string
+		Complete bool
+	}{
+		{
+			Name: "Diamond",
+			Children: [][]int{
+				0: {1, 2},
+				1: {3, 4},
+				2: {4},
+				3: {4},
+				4: {},
+			},
+			From:     "0",
+			To:       "4",
+			Limit:    10,
+			Expected: [][]string{{"0", "1", "4"}, {"0", "2", "4"}, {"0", "1", "3", "4"}},
+			Complete: true,
+		},
+		{
+			Name: "Cycles are not followed",
+			Children: [][]int{
+				0: {1},
+				1: {2, 0},
+				2: {1, 3},
+				3: {},
+			},
+			From:     "0",
+			To:       "3",
+			Limit:    10,
+			Expected: [][]string{{"0", "1", "2", "3"}},
+			Complete: true,
+		},
+		{
+			Name: "Unreachable",
+			Children: [][]int{
+				0: {1},
+				1: {},
+			},
+			From:     "1",
+			To:       "0",
+			Limit:    10,
+			Complete: true,
+		},
+		{
+			Name: "Limited",
+			Children: [][]int{
+				0: {1, 2},
+				1: {3, 4},
+				2: {4},
+				3: {4},
+				4: {},
+			},
+			From:     "0",
+			To:       "4",
+			Limit:    2,
+			Expected: [][]string{{"0", "1", "4"}, {"0", "2", "4"}},
+			Complete: false,
+		},
+		{
+			Name: "Limit matches the amount of paths",
+			Children: [][]int{
+				0: {1, 2},
+				1: {3, 4},
+				2: {4},
+				3: {4},
+				4: {},
+			},
+			From:     "0",
+			To:       "4",
+			Limit:    3,
+			Expected: [][]string{{"0", "1", "4"}, {"0", "2", "4"}, {"0", "1", "3", "4"}},
+			Complete: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			g := MakeTestGraph(tt.Children)
+			paths, complete := g.SimplePaths(tt.From, tt.To, tt.Limit)
+			a.Equal(tt.Expected, paths)
+			a.Equal(tt.Complete, complete)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// RedundantEdge is a direct dependency that is already implied by a longer path.
+type RedundantEdge struct {
+	From string
+	To   string
+	// Path is a longer path that goes from From to To, including both.
+	Path []string
+}
+
+// TransitiveReduction removes the edges that are implied by longer paths, leaving only the
+// ones that are essential for keeping the same nodes reachable from each other. The graph
+// must not have cycles, so they need to be removed first with RemoveCycles.
+func (g *Graph[T]) TransitiveReduction() ([]RedundantEdge, error) {
+	if _, err := topo.Sort(g); err != nil {
+		return nil, errors.New("the transitive reduction can only be computed in a graph without cycles")
+	}
+	var result []RedundantEdge
+	for _, node := range g.AllNodes() {
+		result = append(result, g.redundantEdgesFrom(node)...)
+	}
+	for _, edge := range result {
+		g.RemoveFromToEdge(edge.From, edge.To)
+	}
+	return result, nil
+}
+
+// redundantEdgesFrom traverses everything that is reachable from the children of node
+// without using the direct edge. A child found that way can be reached through a longer path.
+func (g *Graph[T]) redundantEdgesFrom(node *Node[T]) []RedundantEdge {
+	children := g.FromId(node.Id)
+	parents := map[string]string{}
+	var queue []*Node[T]
+	for _, child := range children {
+		for _, next := range g.FromId(child.Id) {
+			if _, ok := parents[next.Id]; !ok {
+				parents[next.Id] = child.Id
+				queue = append(queue, next)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, next := range g.FromId(current.Id) {
+			if _, ok := parents[next.Id]; !ok {
+				parents[next.Id] = current.Id
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	isChild := make(map[string]bool, len(children))
+	for _, child := range children {
+		isChild[child.Id] = true
+	}
+	var result []RedundantEdge
+	for _, child := range children {
+		if _, ok := parents[child.Id]; !ok {
+			continue
+		}
+		// Walk the path backwards until reaching another child of node.
+		path := []string{child.Id}
+		for id := parents[child.Id]; ; id = parents[id] {
+			path = append(path, id)
+			if isChild[id] {
+				break
+			}
+		}
+		path = append(path, node.Id)
+		slices.Reverse(path)
+		result = append(result, RedundantEdge{From: node.Id, To: child.Id, Path: path})
+	}
+	return result
+}
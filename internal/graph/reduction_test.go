@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraph_TransitiveReduction(t *testing.T) {
+	tests := []struct {
+		Name      string
+		Children  [][]int
+		Expected  []RedundantEdge
+		Remaining [][]int
+	}{
+		{
+			Name: "Nothing to reduce",
+			Children: [][]int{
+				0: {1, 2},
+				1: {3},
+				2: {3},
+				3: {},
+			},
+			Remaining: [][]int{
+				0: {1, 2},
+				1: {3},
+				2: {3},
+				3: {},
+			},
+		},
+		{
+			Name: "Shortcut",
+			Children: [][]int{
+				0: {1, 2},
+				1: {2},
+				2: {},
+			},
+			Expected: []RedundantEdge{
+				{From: "0", To: "2", Path: []string{"0", "1", "2"}},
+			},
+			Remaining: [][]int{
+				0: {1},
+				1: {2},
+				2: {},
+			},
+		},
+		{
+			Name: "Long paths",
+			Children: [][]int{
+				0: {1, 3, 4},
+				1: {2},
+				2: {3},
+				3: {4},
+				4: {},
+			},
+			Expected: []RedundantEdge{
+				{From: "0", To: "3", Path: []string{"0", "1", "2", "3"}},
+				{From: "0", To: "4", Path: []string{"0", "3", "4"}},
+			},
+			Remaining: [][]int{
+				0: {1},
+				1: {2},
+				2: {3},
+				3: {4},
+				4: {},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			a := require.New(t)
+			g := MakeTestGraph(tt.Children)
+			redundant, err := g.TransitiveReduction()
+			a.NoError(err)
+			a.Equal(tt.Expected, redundant)
+
+			remaining := make([][]int, len(tt.Children))
+			for i := range tt.Children {
+				remaining[i] = []int{}
+				for _, to := range g.FromId(strconv.Itoa(i)) {
+					remaining[i] = append(remaining[i], to.Data)
+				}
+			}
+			a.Equal(tt.Remaining, remaining)
+		})
+	}
+}
+
+func TestGraph_TransitiveReduction_Cycles(t *testing.T) {
+	a := require.New(t)
+	g := MakeTestGraph([][]int{
+		0: {1},
+		1: {0},
+	})
+	_, err := g.TransitiveReduction()
+	a.ErrorContains(err, "without cycles")
+}
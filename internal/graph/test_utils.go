@@ -46,9 +46,14 @@ func MakeTestGraph(spec [][]int) *Graph[int] {
 
 type TestParser struct {
 	Spec [][]int
+	Jobs int
 }
 
-var _ NodeParser[[]int] = &TestParser{}
+var _ ParallelNodeParser[[]int] = &TestParser{}
+
+func (t *TestParser) Parallelism() int {
+	return t.Jobs
+}
 
 func (t *TestParser) Node(id string) (*Node[[]int], error) {
 	idInt, err := strconv.Atoi(id)
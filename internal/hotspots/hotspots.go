@@ -0,0 +1,73 @@
+package hotspots
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/revision"
+)
+
+// Hotspot is a file that changes often, and how risky it is to change it.
+type Hotspot struct {
+	File *graph.Node[*language.FileInfo]
+	// Commits is the amount of commits that changed the file.
+	Commits int
+	// LinesChanged is the amount of lines added and removed by those commits.
+	LinesChanged int
+	Loc          int
+	// FanIn is the amount of files that depend on the file.
+	FanIn int
+	// Score is the product of the churn, the size and the fan-in of the file, each of them
+	// relative to the highest one among all the files, from 0 to 1.
+	Score float64
+}
+
+// Rank combines how much each file in g changed according to churn, which is keyed by the
+// file ids, with its size and its fan-in. Files that did not change are left out, and the
+// riskiest files come first.
+func Rank(g *graph.Graph[*language.FileInfo], churn map[string]revision.Churn) []Hotspot {
+	var result []Hotspot
+	for _, node := range g.AllNodes() {
+		c, ok := churn[node.Id]
+		if !ok {
+			continue
+		}
+		result = append(result, Hotspot{
+			File:         node,
+			Commits:      c.Commits,
+			LinesChanged: c.Additions + c.Deletions,
+			Loc:          node.Data.Loc,
+			FanIn:        len(g.ToId(node.Id)),
+		})
+	}
+
+	maxCommits, maxLinesChanged, maxLoc, maxFanIn := 1, 1, 1, 0
+	for _, h := range result {
+		maxCommits = max(maxCommits, h.Commits)
+		maxLinesChanged = max(maxLinesChanged, h.LinesChanged)
+		maxLoc = max(maxLoc, h.Loc)
+		maxFanIn = max(maxFanIn, h.FanIn)
+	}
+	for i, h := range result {
+		churnScore := (float64(h.Commits)/float64(maxCommits) + float64(h.LinesChanged)/float64(maxLinesChanged)) / 2
+		sizeScore := float64(h.Loc) / float64(maxLoc)
+		// Files without dependants, like entrypoints, are still risky if they are big and change a lot.
+		fanInScore := float64(h.FanIn+1) / float64(maxFanIn+1)
+		// Rounded for avoiding ties being broken by floating point noise.
+		result[i].Score = math.Round(churnScore*sizeScore*fanInScore*1e4) / 1e4
+	}
+
+	slices.SortStableFunc(result, func(a, b Hotspot) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Commits, a.Commits); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.File.Data.RelPath, b.File.Data.RelPath)
+	})
+	return result
+}
@@ -0,0 +1,43 @@
+package hotspots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+	"github.com/gabotechs/dep-tree/internal/revision"
+)
+
+func TestRank(t *testing.T) {
+	a := require.New(t)
+	g := graph.NewGraph[*language.FileInfo]()
+	for file, loc := range map[string]int{"main.py": 50, "core.py": 200, "utils.py": 20, "unused.py": 100} {
+		g.AddNode(graph.MakeNode(file, &language.FileInfo{RelPath: file, Loc: loc}))
+	}
+	a.NoError(g.AddFromToEdge("main.py", "core.py", "utils.py"))
+	a.NoError(g.AddFromToEdge("core.py", "utils.py"))
+
+	hotspots := Rank(g, map[string]revision.Churn{
+		"main.py":    {Commits: 10, Additions: 80, Deletions: 20},
+		"core.py":    {Commits: 5, Additions: 150, Deletions: 50},
+		"utils.py":   {Commits: 2, Additions: 10},
+		"deleted.py": {Commits: 20, Additions: 500},
+	})
+
+	var files []string
+	for _, h := range hotspots {
+		files = append(files, h.File.Id)
+	}
+	a.Equal([]string{"core.py", "main.py", "utils.py"}, files)
+	a.Equal(Hotspot{
+		File:         g.Get("core.py"),
+		Commits:      5,
+		LinesChanged: 200,
+		Loc:          200,
+		FanIn:        1,
+		Score:        0.5,
+	}, hotspots[0])
+	a.InDelta(0.75*0.25*(1.0/3), hotspots[1].Score, 1e-4)
+}
@@ -11,3 +11,7 @@ import { Unexisting } from './1/a'
 const two = require('./2/2')
 let { a, b } = require('./2/2')
 require('./1/a')
+// @ts-ignore
+import type { C } from './2/2'
+import { useState } from 'react'
+import { readFile } from 'node:fs'
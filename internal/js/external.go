@@ -0,0 +1,36 @@
+package js
+
+import (
+	"slices"
+	"strings"
+)
+
+// nodeBuiltins are the modules that come with Node.js, they are not npm packages.
+var nodeBuiltins = []string{
+	"assert", "async_hooks", "buffer", "child_process", "cluster", "console", "constants",
+	"crypto", "dgram", "diagnostics_channel", "dns", "domain", "events", "fs", "http", "http2",
+	"https", "inspector", "module", "net", "os", "path", "perf_hooks", "process", "punycode",
+	"querystring", "readline", "repl", "stream", "string_decoder", "sys", "timers", "tls",
+	"trace_events", "tty", "url", "util", "v8", "vm", "wasi", "worker_threads", "zlib",
+}
+
+// npmPackage returns the name of the npm package imported by a bare specifier, like react for
+// react/jsx-runtime or @scope/pkg for @scope/pkg/utils, or an empty string if the specifier
+// does not point to an npm package.
+func npmPackage(specifier string) string {
+	if strings.HasPrefix(specifier, ".") || strings.HasPrefix(specifier, "/") || strings.Contains(specifier, ":") {
+		return ""
+	}
+	parts := strings.Split(specifier, "/")
+	if strings.HasPrefix(specifier, "@") {
+		// @/foo is usually an alias for a local directory.
+		if len(parts) < 2 || parts[0] == "@" || parts[1] == "" {
+			return ""
+		}
+		return parts[0] + "/" + parts[1]
+	}
+	if slices.Contains(nodeBuiltins, parts[0]) {
+		return ""
+	}
+	return parts[0]
+}
@@ -0,0 +1,33 @@
+package js
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNpmPackage(t *testing.T) {
+	tests := []struct {
+		Specifier string
+		Expected  string
+	}{
+		{Specifier: "react", Expected: "react"},
+		{Specifier: "react/jsx-runtime", Expected: "react"},
+		{Specifier: "@scope/pkg", Expected: "@scope/pkg"},
+		{Specifier: "@scope/pkg/utils", Expected: "@scope/pkg"},
+		{Specifier: "@scope", Expected: ""},
+		{Specifier: "@/components", Expected: ""},
+		{Specifier: "fs", Expected: ""},
+		{Specifier: "fs/promises", Expected: ""},
+		{Specifier: "node:fs", Expected: ""},
+		{Specifier: "./foo", Expected: ""},
+		{Specifier: "/foo", Expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Specifier, func(t *testing.T) {
+			a := require.New(t)
+			a.Equal(tt.Expected, npmPackage(tt.Specifier))
+		})
+	}
+}
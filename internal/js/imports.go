@@ -14,13 +14,14 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 	content := file.Content.(*js_grammar.File)
 	for _, stmt := range content.Statements {
 		importPath := ""
-		entry := language.ImportEntry{}
+		entry := language.ImportEntry{Line: stmt.Pos.Line}
 
 		switch {
 		case stmt == nil:
 			continue
 		case stmt.StaticImport != nil:
 			importPath = stmt.StaticImport.Path
+			entry.TypeOnly = stmt.StaticImport.TypeOnly
 			if imported := stmt.StaticImport.Imported; imported != nil {
 				if imported.Default {
 					entry.Symbols = append(entry.Symbols, "default")
@@ -39,6 +40,7 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 		case stmt.DynamicImport != nil:
 			importPath = stmt.DynamicImport.Path
 			entry.All = true
+			entry.Dynamic = true
 		case stmt.Require != nil:
 			importPath = stmt.Require.Path
 			entry.All = stmt.Require.Alias != ""
@@ -48,6 +50,11 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 		}
 		var err error
 		entry.AbsPath, err = l.ResolvePath(importPath, filepath.Dir(file.AbsPath))
+		if err == nil && entry.AbsPath == "" {
+			if pkg := npmPackage(importPath); pkg != "" {
+				entry.AbsPath = language.ExternalId(language.NpmEcosystem, pkg)
+			}
+		}
 		if err != nil {
 			errors = append(errors, err)
 		} else if entry.AbsPath != "" {
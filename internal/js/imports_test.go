@@ -25,14 +25,16 @@ func TestParser_parseImports(t *testing.T) {
 			Name: "test 1",
 			File: filepath.Join(importsTestFolder, "index.ts"),
 			Expected: []language.ImportEntry{
-				{Symbols: []string{"a", "b"}, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts")},
-				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "2", "index.ts")},
-				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "a.ts")},
-				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "index.ts")},
-				{Symbols: []string{"Unexisting"}, AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "index.ts")},
-				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts")},
-				{Symbols: []string{"a", "b"}, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts")},
-				{AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "index.ts")},
+				{Symbols: []string{"a", "b"}, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts"), Line: 2},
+				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "2", "index.ts"), Line: 3},
+				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "a.ts"), Line: 4},
+				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "index.ts"), Line: 6, Dynamic: true},
+				{Symbols: []string{"Unexisting"}, AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "index.ts"), Line: 10},
+				{All: true, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts"), Line: 11},
+				{Symbols: []string{"a", "b"}, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts"), Line: 12},
+				{AbsPath: filepath.Join(wd, importsTestFolder, "1", "a", "index.ts"), Line: 13},
+				{Symbols: []string{"C"}, AbsPath: filepath.Join(wd, importsTestFolder, "2", "2.ts"), Line: 15, TypeOnly: true},
+				{Symbols: []string{"useState"}, AbsPath: "npm:react", Line: 16},
 			},
 			ExpectedErrors: []string{
 				"could not perform relative import for './unexisting'",
@@ -11,7 +11,9 @@ type ExportDeconstruction struct {
 }
 
 type DeclarationExport struct {
-	Name string `"export" "async"? ("let"|"const"|"var"|"function"|"class"|"type"|"interface"|"enum") ALL? @Ident`
+	Abstract bool   `"export" "async"? @"abstract"?`
+	Kind     string `@("let"|"const"|"var"|"function"|"class"|"type"|"interface"|"enum")`
+	Name     string `ALL? @Ident`
 }
 
 type ListExport struct {
@@ -37,6 +37,10 @@ func TestExport(t *testing.T) {
 			Name:                `export class ClassName { /* … */ }`,
 			ExpectedDeclaration: []string{"ClassName"},
 		},
+		{
+			Name:                `export abstract class ClassName { /* … */ }`,
+			ExpectedDeclaration: []string{"ClassName"},
+		},
 		{
 			Name:                `export function* generatorFunctionName() { /* … */ }`,
 			ExpectedDeclaration: []string{"generatorFunctionName"},
@@ -13,6 +13,7 @@ import (
 )
 
 type Statement struct {
+	Pos lexer.Position
 	// imports.
 	DynamicImport *DynamicImport `  @@`
 	StaticImport  *StaticImport  `| @@`
@@ -58,10 +59,36 @@ func Parse(filePath string) (*language.FileInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	types, abstractTypes := countTypes(statements)
 	return &language.FileInfo{
-		Content: statements,
-		Loc:     bytes.Count(content, []byte("\n")),
-		Size:    len(content),
-		AbsPath: filePath,
+		Content:       statements,
+		Loc:           bytes.Count(content, []byte("\n")),
+		Size:          len(content),
+		AbsPath:       filePath,
+		Types:         types,
+		AbstractTypes: abstractTypes,
 	}, nil
 }
+
+// countTypes counts the exported type declarations, interfaces and abstract classes being
+// the abstract ones.
+func countTypes(file *File) (types int, abstractTypes int) {
+	for _, stmt := range file.Statements {
+		if stmt.DeclarationExport == nil {
+			continue
+		}
+		switch stmt.DeclarationExport.Kind {
+		case "interface":
+			types++
+			abstractTypes++
+		case "class":
+			types++
+			if stmt.DeclarationExport.Abstract {
+				abstractTypes++
+			}
+		case "type", "enum":
+			types++
+		}
+	}
+	return types, abstractTypes
+}
@@ -45,3 +45,21 @@ func TestGrammar(t *testing.T) {
 		})
 	}
 }
+
+func TestCountTypes(t *testing.T) {
+	a := require.New(t)
+	parsed, err := parser.ParseBytes("", []byte(`
+export interface Shape {}
+export abstract class Base {}
+export class Square extends Base {}
+export type Id = string
+export enum Color { Red }
+export const square = new Square()
+export function area() {}
+interface Private {}
+`))
+	a.NoError(err)
+	types, abstractTypes := countTypes(parsed)
+	a.Equal(5, types)
+	a.Equal(2, abstractTypes)
+}
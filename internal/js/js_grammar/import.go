@@ -20,7 +20,8 @@ type Imported struct {
 }
 
 type StaticImport struct {
-	Imported *Imported `"import" "type"? (@@ "from")?`
+	TypeOnly bool      `"import" @"type"?`
+	Imported *Imported `(@@ "from")?`
 	Path     string    `@String`
 }
 
@@ -18,12 +18,7 @@ type Language struct {
 
 var _ language.Language = &Language{}
 
-var findFirstPackageJsonWithNameCache = map[string]*packageJson{}
-
-func findFirstPackageJsonWithName(searchPath string) *packageJson {
-	if result, ok := findFirstPackageJsonWithNameCache[searchPath]; ok {
-		return result
-	}
+func _findFirstPackageJsonWithName(searchPath string) *packageJson {
 	packageJsonPath := filepath.Join(searchPath, packageJsonFile)
 	if utils.FileExists(packageJsonPath) {
 		pckJson, _ := readPackageJson(packageJsonPath)
@@ -33,11 +28,13 @@ func findFirstPackageJsonWithName(searchPath string) *packageJson {
 	}
 	nextSearchPath := filepath.Dir(searchPath)
 	if nextSearchPath != searchPath {
-		findFirstPackageJsonWithNameCache[searchPath] = findFirstPackageJsonWithName(nextSearchPath)
+		return _findFirstPackageJsonWithName(nextSearchPath)
 	}
-	return findFirstPackageJsonWithNameCache[searchPath]
+	return nil
 }
 
+var findFirstPackageJsonWithName = utils.Cached1In1Out(_findFirstPackageJsonWithName)
+
 func MakeJsLanguage(cfg *Config) (language.Language, error) {
 	return &Language{Cfg: cfg}, nil
 }
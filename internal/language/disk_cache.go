@@ -0,0 +1,272 @@
+package language
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// DiskCache is a Language that persists the results of another Language in a directory,
+// so that files that did not change since a previous run do not need to be parsed again.
+//
+// Results are keyed by the content of the file, its path, the wrapped Language, a
+// Fingerprint of the configuration they were computed with and the format of the entries.
+// As imports might resolve to the file that declares each symbol, like in Go, a cached
+// result is discarded if any of the files it resolved to changed or does not exist anymore.
+// New files that would change how imports are resolved are not detected, in that case the
+// cache directory needs to be removed. Languages that are a StatefulLanguage are not cached.
+type DiskCache struct {
+	Lang Language
+	// Dir is the directory where results are stored, it's created if it does not exist.
+	Dir string
+	// Fingerprint identifies the configuration that affects the parsing results.
+	Fingerprint string
+	// hashes memoizes the content hashes of the resolved files, as files do not change
+	// during a run and many files resolve to the same ones.
+	hashes sync.Map
+}
+
+// cacheFormatVersion is part of the key of every entry, it needs to be bumped whenever
+// diskCacheEntry, or anything it contains like ImportEntry, changes.
+const cacheFormatVersion = 2
+
+func NewDiskCache(lang Language, dir string, fingerprint string) *DiskCache {
+	return &DiskCache{Lang: lang, Dir: dir, Fingerprint: fingerprint}
+}
+
+var _ Language = &DiskCache{}
+
+type diskCacheEntry struct {
+	RelPath       string
+	Package       string
+	Loc           int
+	Size          int
+	Types         int
+	AbstractTypes int
+	Imports       []ImportEntry
+	ImportErrors  []string
+	Exports       []ExportEntry
+	ExportErrors  []string
+	// Resolved maps the files that imports and exports resolved to to their content hash.
+	Resolved map[string]string
+}
+
+// diskCacheContent is placed in FileInfo.Content by DiskCache.
+type diskCacheContent struct {
+	imports    *ImportsResult
+	importsErr error
+	exports    *ExportsResult
+	exportsErr error
+}
+
+func toStrings(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+	result := make([]string, len(errs))
+	for i, err := range errs {
+		result[i] = err.Error()
+	}
+	return result
+}
+
+func toErrors(msgs []string) []error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	result := make([]error, len(msgs))
+	for i, msg := range msgs {
+		result[i] = errors.New(msg)
+	}
+	return result
+}
+
+func (d *DiskCache) key(path string, content []byte) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%d\x00%T\x00%s\x00%s\x00", cacheFormatVersion, d.Lang, d.Fingerprint, path)
+	_, _ = h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (d *DiskCache) entryPath(key string) string {
+	return filepath.Join(d.Dir, key[:2], key+".json")
+}
+
+func (d *DiskCache) load(key string) (*diskCacheEntry, bool) {
+	content, err := os.ReadFile(d.entryPath(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry diskCacheEntry
+	if json.Unmarshal(content, &entry) != nil {
+		return nil, false
+	}
+	for resolved, hash := range entry.Resolved {
+		if current, ok := d.hash(resolved); !ok || current != hash {
+			return nil, false
+		}
+	}
+	return &entry, true
+}
+
+// hash returns the content hash of the file at path, or false if it cannot be read.
+// Directories, that some languages resolve to, are only checked for existence.
+func (d *DiskCache) hash(path string) (string, bool) {
+	if hash, ok := d.hashes.Load(path); ok {
+		return hash.(string), hash != ""
+	}
+	var hash string
+	if info, err := os.Stat(path); err != nil {
+		hash = ""
+	} else if info.IsDir() {
+		hash = "dir"
+	} else if content, err := os.ReadFile(path); err == nil {
+		sum := sha256.Sum256(content)
+		hash = hex.EncodeToString(sum[:])
+	}
+	d.hashes.Store(path, hash)
+	return hash, hash != ""
+}
+
+// resolved hashes the files that the imports and exports of path resolved to.
+func (d *DiskCache) resolved(path string, imports []ImportEntry, exports []ExportEntry) (map[string]string, bool) {
+	paths := make([]string, 0, len(imports)+len(exports))
+	for _, imported := range imports {
+		if !IsExternal(imported.AbsPath) {
+			paths = append(paths, imported.AbsPath)
+		}
+	}
+	for _, exported := range exports {
+		paths = append(paths, exported.AbsPath)
+	}
+	result := map[string]string{}
+	for _, resolved := range paths {
+		if resolved == path {
+			continue
+		}
+		hash, ok := d.hash(resolved)
+		if !ok {
+			return nil, false
+		}
+		result[resolved] = hash
+	}
+	return result, true
+}
+
+func (d *DiskCache) store(key string, entry *diskCacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(d.entryPath(key)), os.ModePerm); err != nil {
+		return err
+	}
+	// The cache directory is not meant to be committed.
+	gitignore := filepath.Join(d.Dir, ".gitignore")
+	if _, err = os.Stat(gitignore); err != nil {
+		_ = os.WriteFile(gitignore, []byte("*\n"), 0o644)
+	}
+	// Write to a temporary file first, so that concurrent runs never read half-written entries.
+	tmp, err := os.CreateTemp(filepath.Dir(d.entryPath(key)), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), d.entryPath(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// ParseFile returns the cached results for path if its content did not change, otherwise
+// it parses the file, imports and exports with the wrapped Language and stores them. Files
+// are always parsed by the wrapped Language if it's currently a StatefulLanguage.
+func (d *DiskCache) ParseFile(path string) (*FileInfo, error) {
+	if stateful, ok := d.Lang.(StatefulLanguage); ok && stateful.IsStateful() {
+		return d.Lang.ParseFile(path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := d.key(path, content)
+
+	if entry, ok := d.load(key); ok {
+		return &FileInfo{
+			Content: &diskCacheContent{
+				imports: &ImportsResult{Imports: entry.Imports, Errors: toErrors(entry.ImportErrors)},
+				exports: &ExportsResult{Exports: entry.Exports, Errors: toErrors(entry.ExportErrors)},
+			},
+			AbsPath:       path,
+			RelPath:       entry.RelPath,
+			Package:       entry.Package,
+			Loc:           entry.Loc,
+			Size:          entry.Size,
+			Types:         entry.Types,
+			AbstractTypes: entry.AbstractTypes,
+		}, nil
+	}
+
+	file, err := d.Lang.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &diskCacheContent{}
+	result.imports, result.importsErr = d.Lang.ParseImports(file)
+	result.exports, result.exportsErr = d.Lang.ParseExports(file)
+
+	// Only successful results are persisted, fatal errors are retried in the next run.
+	var resolved map[string]string
+	ok := result.importsErr == nil && result.exportsErr == nil
+	if ok {
+		resolved, ok = d.resolved(path, result.imports.Imports, result.exports.Exports)
+	}
+	if ok {
+		entry := &diskCacheEntry{
+			RelPath:       file.RelPath,
+			Package:       file.Package,
+			Loc:           file.Loc,
+			Size:          file.Size,
+			Types:         file.Types,
+			AbstractTypes: file.AbstractTypes,
+			Imports:       result.imports.Imports,
+			ImportErrors:  toStrings(result.imports.Errors),
+			Exports:       result.exports.Exports,
+			ExportErrors:  toStrings(result.exports.Errors),
+			Resolved:      resolved,
+		}
+		// Failing to write the cache should not make the run fail, it will just be slower next time.
+		_ = d.store(key, entry)
+	}
+
+	cached := *file
+	cached.Content = result
+	return &cached, nil
+}
+
+func (d *DiskCache) ParseImports(file *FileInfo) (*ImportsResult, error) {
+	content, ok := file.Content.(*diskCacheContent)
+	if !ok {
+		return d.Lang.ParseImports(file)
+	}
+	return content.imports, content.importsErr
+}
+
+func (d *DiskCache) ParseExports(file *FileInfo) (*ExportsResult, error) {
+	content, ok := file.Content.(*diskCacheContent)
+	if !ok {
+		return d.Lang.ParseExports(file)
+	}
+	return content.exports, content.exportsErr
+}
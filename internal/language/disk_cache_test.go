@@ -0,0 +1,188 @@
+package language
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// linesLanguage imports every file whose name is written in a line of the parsed file,
+// and counts how many times files are parsed.
+type linesLanguage struct {
+	parsed atomic.Int32
+}
+
+func (l *linesLanguage) ParseFile(path string) (*FileInfo, error) {
+	l.parsed.Add(1)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &FileInfo{
+		Content: strings.Fields(string(content)),
+		AbsPath: path,
+		RelPath: filepath.Base(path),
+		Loc:     len(strings.Fields(string(content))),
+		Size:    len(content),
+	}, nil
+}
+
+func (l *linesLanguage) ParseImports(file *FileInfo) (*ImportsResult, error) {
+	result := &ImportsResult{}
+	for _, name := range file.Content.([]string) {
+		if name == "fail" {
+			return nil, errors.New("failed")
+		}
+		path := filepath.Join(filepath.Dir(file.AbsPath), name)
+		if _, err := os.Stat(path); err != nil {
+			result.Errors = append(result.Errors, errors.New(name+" not found"))
+		} else {
+			result.Imports = append(result.Imports, EmptyImport(path))
+		}
+	}
+	return result, nil
+}
+
+func (l *linesLanguage) ParseExports(file *FileInfo) (*ExportsResult, error) {
+	return &ExportsResult{
+		Exports: []ExportEntry{{Symbols: []ExportSymbol{{Original: file.RelPath}}, AbsPath: file.AbsPath}},
+	}, nil
+}
+
+func TestDiskCache(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, ".dep-tree-cache")
+	write := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		a.NoError(os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	main := write("main", "a\nmissing")
+	a_ := write("a", "")
+
+	parse := func(lang Language, fingerprint string) (*FileInfo, *ImportsResult, *ExportsResult) {
+		cache := NewDiskCache(lang, cacheDir, fingerprint)
+		file, err := cache.ParseFile(main)
+		a.NoError(err)
+		imports, err := cache.ParseImports(file)
+		a.NoError(err)
+		exports, err := cache.ParseExports(file)
+		a.NoError(err)
+		return file, imports, exports
+	}
+
+	lang := &linesLanguage{}
+	file, imports, exports := parse(lang, "1")
+	a.Equal(int32(1), lang.parsed.Load())
+	a.Equal("main", file.RelPath)
+	a.Equal(2, file.Loc)
+	a.Equal([]ImportEntry{EmptyImport(a_)}, imports.Imports)
+	a.Equal([]error{errors.New("missing not found")}, imports.Errors)
+	a.Equal(main, exports.Exports[0].AbsPath)
+	a.FileExists(filepath.Join(cacheDir, ".gitignore"))
+
+	// Same content and fingerprint, served from disk.
+	lang = &linesLanguage{}
+	cachedFile, cachedImports, cachedExports := parse(lang, "1")
+	a.Equal(int32(0), lang.parsed.Load())
+	a.Equal(file.RelPath, cachedFile.RelPath)
+	a.Equal(file.Loc, cachedFile.Loc)
+	a.Equal(file.Size, cachedFile.Size)
+	a.Equal(imports, cachedImports)
+	a.Equal(exports, cachedExports)
+
+	// Changing a file that was resolved invalidates the entry, as resolution might depend
+	// on what that file declares.
+	write("a", "changed")
+	lang = &linesLanguage{}
+	parse(lang, "1")
+	a.Equal(int32(1), lang.parsed.Load())
+	lang = &linesLanguage{}
+	parse(lang, "1")
+	a.Equal(int32(0), lang.parsed.Load())
+
+	// A different fingerprint does not hit the cache.
+	lang = &linesLanguage{}
+	parse(lang, "2")
+	a.Equal(int32(1), lang.parsed.Load())
+
+	// Changing the content does not hit the cache.
+	write("main", "a")
+	lang = &linesLanguage{}
+	_, imports, _ = parse(lang, "1")
+	a.Equal(int32(1), lang.parsed.Load())
+	a.Empty(imports.Errors)
+
+	// Removing a file that was resolved invalidates the entry.
+	a.NoError(os.Remove(a_))
+	lang = &linesLanguage{}
+	_, imports, _ = parse(lang, "1")
+	a.Equal(int32(1), lang.parsed.Load())
+	a.Empty(imports.Imports)
+}
+
+func TestDiskCache_FatalErrorsAreNotStored(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main")
+	a.NoError(os.WriteFile(path, []byte("fail"), 0o644))
+
+	for i := 0; i < 2; i++ {
+		lang := &linesLanguage{}
+		cache := NewDiskCache(lang, filepath.Join(dir, ".dep-tree-cache"), "")
+		file, err := cache.ParseFile(path)
+		a.NoError(err)
+		_, err = cache.ParseImports(file)
+		a.ErrorContains(err, "failed")
+		a.Equal(int32(1), lang.parsed.Load())
+	}
+}
+
+// statefulLanguage is a linesLanguage whose results depend on the files parsed before.
+type statefulLanguage struct {
+	linesLanguage
+}
+
+func (l *statefulLanguage) IsStateful() bool { return true }
+
+func TestDiskCache_StatefulLanguagesAreNotCached(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main")
+	a.NoError(os.WriteFile(path, []byte(""), 0o644))
+	cacheDir := filepath.Join(dir, ".dep-tree-cache")
+
+	for i := 0; i < 2; i++ {
+		lang := &statefulLanguage{}
+		cache := NewDiskCache(lang, cacheDir, "")
+		file, err := cache.ParseFile(path)
+		a.NoError(err)
+		_, err = cache.ParseImports(file)
+		a.NoError(err)
+		a.Equal(int32(1), lang.parsed.Load())
+	}
+	a.NoDirExists(cacheDir)
+}
+
+func TestDiskCache_Parser(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	a.NoError(os.WriteFile(filepath.Join(dir, "main"), []byte("a"), 0o644))
+	a.NoError(os.WriteFile(filepath.Join(dir, "a"), []byte(""), 0o644))
+
+	for i := 0; i < 2; i++ {
+		parser := NewParser(NewDiskCache(&linesLanguage{}, filepath.Join(dir, ".dep-tree-cache"), ""))
+		node, err := parser.Node(filepath.Join(dir, "main"))
+		a.NoError(err)
+		deps, err := parser.Deps(node)
+		a.NoError(err)
+		a.Len(deps, 1)
+		a.Equal(filepath.Join(dir, "a"), deps[0].Id)
+	}
+}
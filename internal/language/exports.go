@@ -46,8 +46,10 @@ func (p *Parser) parseExports(
 		return nil, errors.New("circular export: " + err.Error())
 	}
 	defer stack.Pop()
+	// NOTE: exports are resolved recursively, and two files might be re-exporting from each
+	//  other from different goroutines, so the computation is not serialized per key.
 	cacheKey := fmt.Sprintf("%s-%t", id, unwrappedExports)
-	if cached, ok := p.ExportsCache[cacheKey]; ok {
+	if cached, ok := p.ExportsCache.Get(cacheKey); ok {
 		return cached, nil
 	}
 
@@ -106,6 +108,6 @@ func (p *Parser) parseExports(
 	}
 
 	result := ExportEntries{Symbols: exports, Errors: exportErrors}
-	p.ExportsCache[cacheKey] = &result
+	p.ExportsCache.Set(cacheKey, &result)
 	return &result, nil
 }
@@ -0,0 +1,38 @@
+package language
+
+import (
+	"slices"
+	"strings"
+)
+
+// Ecosystems of the third-party packages. They prefix the ids of the external nodes, like npm:react.
+const (
+	NpmEcosystem   = "npm"
+	GoEcosystem    = "go"
+	CrateEcosystem = "crate"
+	PypiEcosystem  = "pypi"
+)
+
+var ecosystems = []string{NpmEcosystem, GoEcosystem, CrateEcosystem, PypiEcosystem}
+
+// ExternalId builds the id of the node that represents a third-party package.
+func ExternalId(ecosystem string, name string) string {
+	return ecosystem + ":" + name
+}
+
+// IsExternal tells whether id represents a third-party package instead of a file.
+func IsExternal(id string) bool {
+	ecosystem, _, ok := strings.Cut(id, ":")
+	return ok && slices.Contains(ecosystems, ecosystem)
+}
+
+// ExternalImport builds an ImportEntry where a third-party package is imported. These are
+// only part of the graph if Parser.IncludeExternal is true.
+func ExternalImport(ecosystem string, name string, symbols ...string) ImportEntry {
+	return ImportEntry{Symbols: symbols, AbsPath: ExternalId(ecosystem, name)}
+}
+
+// externalFile is the FileInfo of a third-party package, which is never parsed.
+func externalFile(id string) *FileInfo {
+	return &FileInfo{AbsPath: id, RelPath: id}
+}
@@ -0,0 +1,72 @@
+package language
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// GroupBy returns the name of the group a file belongs to.
+type GroupBy func(file *FileInfo) string
+
+// ParseGroupBy builds a GroupBy out of its textual representation:
+//   - "package" groups files by FileInfo.Package, falling back to their directory if the
+//     language does not know in which package they are.
+//   - "dir" groups files by the directory they are in.
+//   - "dir:N" groups files by the first N directories of their path.
+//
+// Third-party packages are never grouped with the files.
+func ParseGroupBy(spec string) (GroupBy, error) {
+	groupBy, err := parseGroupBy(spec)
+	if err != nil {
+		return nil, err
+	}
+	return func(file *FileInfo) string {
+		if IsExternal(file.AbsPath) {
+			return file.AbsPath
+		}
+		return groupBy(file)
+	}, nil
+}
+
+func parseGroupBy(spec string) (GroupBy, error) {
+	kind, depthStr, hasDepth := strings.Cut(spec, ":")
+	switch {
+	case kind == "package" && !hasDepth:
+		return func(file *FileInfo) string {
+			if file.Package != "" {
+				return file.Package
+			}
+			return dirOf(file, 0)
+		}, nil
+	case kind == "dir" && !hasDepth:
+		return func(file *FileInfo) string { return dirOf(file, 0) }, nil
+	case kind == "dir":
+		depth, err := strconv.Atoi(depthStr)
+		if err != nil || depth < 1 {
+			return nil, fmt.Errorf(`invalid depth "%s" in "%s", it must be a positive integer`, depthStr, spec)
+		}
+		return func(file *FileInfo) string { return dirOf(file, depth) }, nil
+	default:
+		return nil, fmt.Errorf(`invalid grouping "%s", allowed values are "package", "dir" or "dir:<depth>"`, spec)
+	}
+}
+
+// dirOf returns the directory of the file relative to the project's root, keeping only the
+// first depth directories. A depth of 0 keeps all of them.
+func dirOf(file *FileInfo, depth int) string {
+	path := file.RelPath
+	if path == "" {
+		path = file.AbsPath
+	}
+	dir := filepath.ToSlash(filepath.Dir(path))
+	if depth == 0 || dir == "." {
+		return dir
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,77 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGroupBy(t *testing.T) {
+	tests := []struct {
+		Spec     string
+		File     FileInfo
+		Expected string
+		Error    string
+	}{
+		{
+			Spec:     "dir",
+			File:     FileInfo{RelPath: "src/users/api/user.ts"},
+			Expected: "src/users/api",
+		},
+		{
+			Spec:     "dir",
+			File:     FileInfo{RelPath: "index.ts"},
+			Expected: ".",
+		},
+		{
+			Spec:     "dir:2",
+			File:     FileInfo{RelPath: "src/users/api/user.ts"},
+			Expected: "src/users",
+		},
+		{
+			Spec:     "dir:5",
+			File:     FileInfo{RelPath: "src/users/api/user.ts"},
+			Expected: "src/users/api",
+		},
+		{
+			Spec:     "package",
+			File:     FileInfo{RelPath: "src/users/api/user.ts", Package: "users"},
+			Expected: "users",
+		},
+		{
+			Spec:     "package",
+			File:     FileInfo{RelPath: "src/users/api/user.ts"},
+			Expected: "src/users/api",
+		},
+		{
+			Spec:     "dir",
+			File:     FileInfo{AbsPath: "npm:react", RelPath: "npm:react"},
+			Expected: "npm:react",
+		},
+		{
+			Spec:  "dir:0",
+			Error: `invalid depth "0" in "dir:0", it must be a positive integer`,
+		},
+		{
+			Spec:  "package:1",
+			Error: `invalid grouping "package:1", allowed values are "package", "dir" or "dir:<depth>"`,
+		},
+		{
+			Spec:  "file",
+			Error: `invalid grouping "file", allowed values are "package", "dir" or "dir:<depth>"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.Spec, func(t *testing.T) {
+			a := require.New(t)
+			groupBy, err := ParseGroupBy(tt.Spec)
+			if tt.Error != "" {
+				a.EqualError(err, tt.Error)
+				return
+			}
+			a.NoError(err)
+			a.Equal(tt.Expected, groupBy(&tt.File))
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package language
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+// GroupedParser is a graph.NodeParser that collapses the files reachable from some entrypoints
+// into groups, like directories or packages, so that graphs are built out of groups instead of
+// files. Each group carries the summed Loc, Size and types of its files, and the dependency
+// between two groups weighs as many file-level imports as it represents.
+type GroupedParser struct {
+	groupBy GroupBy
+	files   *graph.Graph[*FileInfo]
+	groups  *graph.Graph[*FileInfo]
+}
+
+var _ graph.WeightedNodeParser[*FileInfo] = &GroupedParser{}
+
+// NewGroupedParser loads the file-level graph out of entrypoints using parser, and collapses
+// it using groupBy.
+func NewGroupedParser(
+	parser graph.NodeParser[*FileInfo],
+	groupBy GroupBy,
+	entrypoints []string,
+	callbacks graph.LoadCallbacks[*FileInfo],
+) (*GroupedParser, error) {
+	files := graph.NewGraph[*FileInfo]()
+	err := files.Load(entrypoints, parser, callbacks)
+	if err != nil {
+		return nil, err
+	}
+	groups := graph.Collapse(
+		files,
+		func(node *graph.Node[*FileInfo]) string { return groupBy(node.Data) },
+		mergeFiles,
+	)
+	return &GroupedParser{groupBy: groupBy, files: files, groups: groups}, nil
+}
+
+// mergeFiles builds the FileInfo of a group out of the files in it.
+func mergeFiles(key string, nodes []*graph.Node[*FileInfo]) *FileInfo {
+	first := nodes[0].Data
+	result := &FileInfo{
+		AbsPath: key,
+		RelPath: key,
+		Package: first.Package,
+	}
+	// If the group is a directory, point its AbsPath to it.
+	relPath := filepath.ToSlash(first.RelPath)
+	if key == "." || strings.HasPrefix(relPath, key+"/") {
+		root := strings.TrimSuffix(first.AbsPath, filepath.FromSlash(first.RelPath))
+		result.AbsPath = filepath.Join(root, filepath.FromSlash(key))
+	}
+	for _, node := range nodes {
+		if node.Data.Package != result.Package {
+			result.Package = ""
+		}
+		result.Loc += node.Data.Loc
+		result.Size += node.Data.Size
+		result.Types += node.Data.Types
+		result.AbstractTypes += node.Data.AbstractTypes
+	}
+	return result
+}
+
+// Group returns the id of the group where the file with the given id is. Ids that are not
+// loaded files are returned as they are.
+func (p *GroupedParser) Group(id string) string {
+	if file := p.files.Get(id); file != nil {
+		return p.groupBy(file.Data)
+	}
+	return id
+}
+
+// Groups is the same as Group but for several ids, removing duplicates.
+func (p *GroupedParser) Groups(ids []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		group := p.Group(id)
+		if !seen[group] {
+			seen[group] = true
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// Node returns the group with the given id, or the group where the file with the given id is.
+func (p *GroupedParser) Node(id string) (*graph.Node[*FileInfo], error) {
+	group := p.groups.Get(p.Group(id))
+	if group == nil {
+		return nil, nil
+	}
+	// Nodes can only belong to one graph, so a copy is returned.
+	node := graph.MakeNode(group.Id, group.Data)
+	node.AddErrors(group.Errors...)
+	return node, nil
+}
+
+func (p *GroupedParser) Deps(n *graph.Node[*FileInfo]) ([]*graph.Node[*FileInfo], error) {
+	deps := p.groups.FromId(n.Id)
+	result := make([]*graph.Node[*FileInfo], len(deps))
+	for i, dep := range deps {
+		result[i], _ = p.Node(dep.Id)
+	}
+	return result, nil
+}
+
+// Weight returns how many file-level imports the dependency between two groups represents.
+func (p *GroupedParser) Weight(from string, to string) int {
+	return p.groups.Weight(from, to)
+}
@@ -0,0 +1,77 @@
+package language
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+type specParser map[string][]string
+
+func (s specParser) Node(id string) (*graph.Node[*FileInfo], error) {
+	return graph.MakeNode(id, &FileInfo{
+		AbsPath: "/root/" + id,
+		RelPath: id,
+		Package: "pkg",
+		Loc:     10,
+		Size:    100,
+	}), nil
+}
+
+func (s specParser) Deps(n *graph.Node[*FileInfo]) ([]*graph.Node[*FileInfo], error) {
+	result := make([]*graph.Node[*FileInfo], len(s[n.Id]))
+	for i, dep := range s[n.Id] {
+		result[i], _ = s.Node(dep)
+	}
+	return result, nil
+}
+
+func TestGroupedParser(t *testing.T) {
+	a := require.New(t)
+	parser := specParser{
+		"main.py":        {"users/api.py", "users/db.py", "orders/api.py"},
+		"users/api.py":   {"users/db.py", "orders/api.py"},
+		"users/db.py":    {"db/sql.py"},
+		"orders/api.py":  {"orders/db.py", "users/api.py"},
+		"orders/db.py":   {"db/sql.py"},
+		"db/sql.py":      {},
+		"unreachable.py": {"db/sql.py"},
+	}
+	groupBy, err := ParseGroupBy("dir")
+	a.NoError(err)
+	grouped, err := NewGroupedParser(parser, groupBy, []string{"main.py"}, nil)
+	a.NoError(err)
+
+	a.Equal("users", grouped.Group("users/db.py"))
+	a.Equal("users", grouped.Group("users"))
+	a.Equal([]string{".", "users"}, grouped.Groups([]string{"main.py", "users/api.py", "users/db.py"}))
+
+	g := graph.NewGraph[*FileInfo]()
+	a.NoError(g.Load([]string{"main.py"}, grouped, nil))
+
+	ids := func(nodes []*graph.Node[*FileInfo]) []string {
+		result := make([]string, len(nodes))
+		for i, n := range nodes {
+			result[i] = n.Id
+		}
+		return result
+	}
+	a.Equal([]string{".", "users", "orders", "db"}, ids(g.AllNodes()))
+	a.Equal([]string{"users", "orders"}, ids(g.FromId(".")))
+	a.Equal([]string{"orders", "db"}, ids(g.FromId("users")))
+	a.Equal([]string{"users", "db"}, ids(g.FromId("orders")))
+	a.Equal(2, g.Weight(".", "users"))
+	a.Equal(1, g.Weight("users", "orders"))
+	a.Equal(1, g.Weight("orders", "users"))
+	a.Equal(1, g.Weight("users", "db"))
+
+	users := g.Get("users").Data
+	a.Equal("users", users.RelPath)
+	a.Equal("/root/users", users.AbsPath)
+	a.Equal("pkg", users.Package)
+	a.Equal(20, users.Loc)
+	a.Equal(200, users.Size)
+	a.Equal("/root", g.Get(".").Data.AbsPath)
+}
@@ -20,6 +20,12 @@ type FileInfo struct {
 	Loc int
 	// Size is the size in bytes of the file.
 	Size int
+	// Types is the amount of exported type declarations (structs, classes, interfaces, traits...)
+	// in the file. It is left as 0 by languages that are not able to tell.
+	Types int
+	// AbstractTypes is the amount of exported type declarations that are abstract, like
+	// interfaces, traits or abstract classes.
+	AbstractTypes int
 }
 
 // ImportEntry represents an import statement in a programming language.
@@ -41,6 +47,23 @@ type ImportEntry struct {
 	//   import { baz } from './baz'
 	// will result in an ImportEntry with AbsPath = /foo/baz.ts
 	AbsPath string
+	// Line is the line of the source file where the import is, starting from 1. It is 0 for languages
+	// that are not able to tell.
+	Line int
+	// TypeOnly is true if only types are imported, so the dependency disappears at runtime:
+	// TS -> import type { Foo } from './foo'
+	TypeOnly bool
+	// Dynamic is true if the source file is imported at runtime:
+	// JS -> const foo = await import('./foo')
+	Dynamic bool
+	// Conditional is true if the import might not happen, like the ones inside an if or try statement:
+	// Python -> try:
+	//             import foo
+	Conditional bool
+	// ReExport is true if the symbols are imported for exporting them again:
+	// JS   -> export { foo } from './foo'
+	// Rust -> pub use crate::foo::bar;
+	ReExport bool
 }
 
 // AllImport builds an ImportEntry where all the symbols are imported.
@@ -122,3 +145,12 @@ type Language interface {
 	//  F contains.
 	ParseExports(file *FileInfo) (*ExportsResult, error)
 }
+
+// StatefulLanguage is implemented by the languages that gather state while parsing the imports
+// of a file that later affects how other files are parsed. Their results cannot be persisted
+// by DiskCache, as a cache hit would not gather that state.
+type StatefulLanguage interface {
+	Language
+	// IsStateful tells whether parsing currently depends on the state gathered from other files.
+	IsStateful() bool
+}
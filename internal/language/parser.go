@@ -1,6 +1,9 @@
 package language
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/elliotchance/orderedmap/v2"
 	"github.com/gabotechs/dep-tree/internal/graph"
 	"github.com/gabotechs/dep-tree/internal/utils"
@@ -11,10 +14,17 @@ type Parser struct {
 	UnwrapProxyExports bool
 	Exclude            []string
 	Include            []string
-	// cache
-	FileCache    map[string]*FileInfo
-	ImportsCache map[string]*ImportsResult
-	ExportsCache map[string]*ExportEntries
+	// IncludeExternal adds the third-party packages imported by the files to the graph, as
+	// nodes without dependencies, like npm:react.
+	IncludeExternal bool
+	// Jobs is the maximum amount of files that are parsed in parallel. Lang must be
+	// safe for concurrent use if this is greater than 1.
+	Jobs int
+	// cache, safe for concurrent use.
+	FileCache    *utils.Cache[string, *FileInfo]
+	ImportsCache *utils.Cache[string, *ImportsResult]
+	ExportsCache *utils.Cache[string, *ExportEntries]
+	DepsCache    *utils.Cache[string, *resolvedImports]
 }
 
 func NewParser(lang Language) *Parser {
@@ -22,15 +32,23 @@ func NewParser(lang Language) *Parser {
 		Lang:               lang,
 		UnwrapProxyExports: false,
 		Exclude:            nil,
-		FileCache:          make(map[string]*FileInfo),
-		ImportsCache:       make(map[string]*ImportsResult),
-		ExportsCache:       make(map[string]*ExportEntries),
+		Jobs:               1,
+		FileCache:          utils.NewCache[string, *FileInfo](),
+		ImportsCache:       utils.NewCache[string, *ImportsResult](),
+		ExportsCache:       utils.NewCache[string, *ExportEntries](),
+		DepsCache:          utils.NewCache[string, *resolvedImports](),
 	}
 }
 
-var _ graph.NodeParser[*FileInfo] = &Parser{}
+var _ graph.ParallelNodeParser[*FileInfo] = &Parser{}
+var _ graph.EdgeDataNodeParser[*FileInfo] = &Parser{}
+
+func (p *Parser) Parallelism() int {
+	return p.Jobs
+}
 
-func (p *Parser) shouldExclude(path string) bool {
+// ShouldExclude tells whether a file is left out of the graph because of the Exclude or Include patterns.
+func (p *Parser) ShouldExclude(path string) bool {
 	for _, exclusion := range p.Exclude {
 		if ok, _ := utils.GlobstarMatch(exclusion, path); ok {
 			return true
@@ -49,7 +67,10 @@ func (p *Parser) shouldExclude(path string) bool {
 }
 
 func (p *Parser) Node(id string) (*graph.Node[*FileInfo], error) {
-	if p.shouldExclude(id) {
+	if IsExternal(id) {
+		return graph.MakeNode(id, externalFile(id)), nil
+	}
+	if p.ShouldExclude(id) {
 		return nil, nil
 	}
 	file, err := p.parseFile(id)
@@ -60,11 +81,156 @@ func (p *Parser) Node(id string) (*graph.Node[*FileInfo], error) {
 }
 
 func (p *Parser) Deps(n *graph.Node[*FileInfo]) ([]*graph.Node[*FileInfo], error) {
-	imports, err := p.gatherImportsFromFile(n.Id)
+	if IsExternal(n.Id) {
+		return nil, nil
+	}
+	resolvedImports, errs, err := p.resolveImports(n.Id)
 	if err != nil {
 		return nil, err
 	}
-	n.AddErrors(imports.Errors...)
+	n.AddErrors(errs...)
+
+	deps := make([]*graph.Node[*FileInfo], 0)
+	for _, imported := range resolvedImports.Keys() {
+		node, err := p.Node(imported)
+		if err != nil {
+			n.AddErrors(err)
+		} else if node != nil {
+			deps = append(deps, node)
+		}
+	}
+	return deps, nil
+}
+
+// ImportedSymbols returns the symbols that one file imports from another one. all is true if
+// every symbol is imported, like in `from .foo import *`.
+func (p *Parser) ImportedSymbols(from string, to string) (symbols []string, all bool, err error) {
+	resolvedImports, _, err := p.resolveImports(from)
+	if err != nil {
+		return nil, false, err
+	}
+	if resolved, ok := resolvedImports.Get(to); ok {
+		return resolved.Symbols, resolved.All, nil
+	}
+	return nil, false, nil
+}
+
+// EdgeData returns the *Dependency from one file to another one, so that the graph edges carry
+// what is imported and how.
+func (p *Parser) EdgeData(from string, to string) any {
+	resolvedImports, _, err := p.resolveImports(from)
+	if err != nil {
+		return nil
+	}
+	if resolved, ok := resolvedImports.Get(to); ok {
+		dep := *resolved
+		dep.Symbols = slices.Clone(resolved.Symbols)
+		dep.Lines = slices.Clone(resolved.Lines)
+		return &dep
+	}
+	return nil
+}
+
+// Dependency is what a file imports from another one, gathered from all the ImportEntry that
+// point to it. The kinds of import are only true if all the imports are of that kind, as that
+// is what tells, for example, that a dependency disappears at runtime.
+type Dependency struct {
+	All     bool
+	Symbols []string
+	// Lines are the lines in the source file where the imports are, sorted.
+	Lines       []int
+	TypeOnly    bool
+	Dynamic     bool
+	Conditional bool
+	ReExport    bool
+	imports     int
+}
+
+func (d *Dependency) add(entry ImportEntry, symbols ...string) {
+	if d.imports == 0 {
+		d.TypeOnly, d.Dynamic, d.Conditional, d.ReExport = entry.TypeOnly, entry.Dynamic, entry.Conditional, entry.ReExport
+	} else {
+		d.TypeOnly = d.TypeOnly && entry.TypeOnly
+		d.Dynamic = d.Dynamic && entry.Dynamic
+		d.Conditional = d.Conditional && entry.Conditional
+		d.ReExport = d.ReExport && entry.ReExport
+	}
+	d.imports++
+	d.All = d.All || entry.All
+	for _, symbol := range symbols {
+		if !slices.Contains(d.Symbols, symbol) {
+			d.Symbols = append(d.Symbols, symbol)
+		}
+	}
+	if entry.Line > 0 {
+		if i, found := slices.BinarySearch(d.Lines, entry.Line); !found {
+			d.Lines = slices.Insert(d.Lines, i, entry.Line)
+		}
+	}
+}
+
+// Line returns the first line in the source file where the dependency is imported, or 0 if
+// it is not known.
+func (d *Dependency) Line() int {
+	if len(d.Lines) == 0 {
+		return 0
+	}
+	return d.Lines[0]
+}
+
+// Imported returns the imported symbols, starting with "*" if every symbol is imported.
+func (d *Dependency) Imported() []string {
+	if d.All {
+		return append([]string{"*"}, d.Symbols...)
+	}
+	return slices.Clone(d.Symbols)
+}
+
+// Kinds returns the names of the kinds of import of the dependency, like "type-only".
+func (d *Dependency) Kinds() []string {
+	var kinds []string
+	for _, kind := range []struct {
+		name string
+		is   bool
+	}{
+		{"type-only", d.TypeOnly},
+		{"dynamic", d.Dynamic},
+		{"conditional", d.Conditional},
+		{"re-export", d.ReExport},
+	} {
+		if kind.is {
+			kinds = append(kinds, kind.name)
+		}
+	}
+	return kinds
+}
+
+type resolvedImports struct {
+	deps *orderedmap.OrderedMap[string, *Dependency]
+	errs []error
+}
+
+// resolveImports gathers the files imported by the provided one, together with what is imported
+// from each of them. The returned errors are the non-fatal ones found while resolving the imports.
+func (p *Parser) resolveImports(id string) (*orderedmap.OrderedMap[string, *Dependency], []error, error) {
+	cacheKey := fmt.Sprintf("%s-%t-%t", id, p.UnwrapProxyExports, p.IncludeExternal)
+	result, err := p.DepsCache.GetOrCompute(cacheKey, func() (*resolvedImports, error) {
+		deps, errs, err := p.computeImports(id)
+		return &resolvedImports{deps, errs}, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.deps, result.errs, nil
+}
+
+func (p *Parser) computeImports(id string) (*orderedmap.OrderedMap[string, *Dependency], []error, error) {
+	imports, err := p.gatherImportsFromFile(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	errs := slices.Clone(imports.Errors)
+	importEntries := slices.Clone(imports.Imports)
 
 	// Some exports might be re-exporting symbols from other files, we consider
 	// those as if they were normal imports.
@@ -79,48 +245,58 @@ func (p *Parser) Deps(n *graph.Node[*FileInfo]) ([]*graph.Node[*FileInfo], error
 	//  technically is true, but it's not true to say that `foo` is imported from `bar.ts`.
 	//  It's more accurate to say that `bar` is imported from `bar.ts`, even if the alias is `foo`.
 	//  Instead, we never unwrap export to avoid this.
-	exports, err := p.parseExports(n.Id, false, nil)
+	exports, err := p.parseExports(id, false, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	n.AddErrors(exports.Errors...)
+	errs = append(errs, exports.Errors...)
 	for el := exports.Symbols.Front(); el != nil; el = el.Next() {
-		if el.Value != n.Id {
-			imports.Imports = append(imports.Imports, ImportEntry{
-				Symbols: []string{el.Key},
-				AbsPath: el.Value,
+		if el.Value != id {
+			importEntries = append(importEntries, ImportEntry{
+				Symbols:  []string{el.Key},
+				AbsPath:  el.Value,
+				ReExport: true,
 			})
 		}
 	}
 
-	resolvedImports := orderedmap.NewOrderedMap[string, bool]()
+	resolvedImports := orderedmap.NewOrderedMap[string, *Dependency]()
+	resolve := func(path string) *Dependency {
+		resolved, ok := resolvedImports.Get(path)
+		if !ok {
+			resolved = &Dependency{}
+			resolvedImports.Set(path, resolved)
+		}
+		return resolved
+	}
 
 	// Imported names might not necessarily be declared in the path that is being imported, they might be declared in
 	// a different file, we want that file. Ex: foo.ts -> utils/index.ts -> utils/sum.ts. If unwrapProxyExports is
 	// set to true, we must trace those exports back.
-	for _, importEntry := range imports.Imports {
-		if !p.UnwrapProxyExports {
-			resolvedImports.Set(importEntry.AbsPath, true)
+	for _, importEntry := range importEntries {
+		// Third-party packages are never parsed, so there are no exports to unwrap.
+		if !p.UnwrapProxyExports || IsExternal(importEntry.AbsPath) {
+			resolve(importEntry.AbsPath).add(importEntry, importEntry.Symbols...)
 			continue
 		}
 
 		// NOTE: at this point p.unwrapProxyExports is always true.
 		exports, err = p.parseExports(importEntry.AbsPath, p.UnwrapProxyExports, nil)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		n.AddErrors(exports.Errors...)
+		errs = append(errs, exports.Errors...)
 		if importEntry.All {
 			// If all imported, then dump every path in the resolved imports.
 			for el := exports.Symbols.Front(); el != nil; el = el.Next() {
-				resolvedImports.Set(el.Value, true)
+				resolve(el.Value).add(importEntry)
 			}
 		} else if len(importEntry.Symbols) == 0 {
-			resolvedImports.Set(importEntry.AbsPath, true)
+			resolve(importEntry.AbsPath).add(importEntry)
 		} else {
 			for _, name := range importEntry.Symbols {
 				if exportPath, ok := exports.Symbols.Get(name); ok {
-					resolvedImports.Set(exportPath, true)
+					resolve(exportPath).add(importEntry, name)
 				} else {
 					// TODO: this is not retro-compatible, do it in a different PR.
 					// n.AddErrors(fmt.Errorf("name %s is imported by %s but not exported by %s", name, n.Id, importEntry.Id)).
@@ -128,15 +304,12 @@ func (p *Parser) Deps(n *graph.Node[*FileInfo]) ([]*graph.Node[*FileInfo], error
 			}
 		}
 	}
-
-	deps := make([]*graph.Node[*FileInfo], 0)
-	for _, imported := range resolvedImports.Keys() {
-		node, err := p.Node(imported)
-		if err != nil {
-			n.AddErrors(err)
-		} else if node != nil {
-			deps = append(deps, node)
+	if !p.IncludeExternal {
+		for _, path := range resolvedImports.Keys() {
+			if IsExternal(path) {
+				resolvedImports.Delete(path)
+			}
 		}
 	}
-	return deps, nil
+	return resolvedImports, errs, nil
 }
@@ -6,7 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParser_shouldExclude(t *testing.T) {
+func TestParser_ShouldExclude(t *testing.T) {
 	tests := []struct {
 		Name     string
 		Paths    []string
@@ -64,7 +64,7 @@ func TestParser_shouldExclude(t *testing.T) {
 			parser := Parser{Exclude: tt.Exclude, Include: tt.Include}
 			var result []string
 			for _, path := range tt.Paths {
-				if !parser.shouldExclude(path) {
+				if !parser.ShouldExclude(path) {
 					result = append(result, path)
 				}
 			}
@@ -351,3 +351,126 @@ func TestParser_DepsErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ImportedSymbols(t *testing.T) {
+	a := require.New(t)
+	lang := &TestLanguage{
+		imports: map[string]*ImportsResult{
+			"1": {
+				Imports: []ImportEntry{
+					{Symbols: []string{"Foo", "Bar"}, AbsPath: "2"},
+					{Symbols: []string{"Foo"}, AbsPath: "2"},
+					{All: true, AbsPath: "3"},
+				},
+			},
+		},
+		exports: map[string]*ExportsResult{
+			"1": {},
+			"2": {
+				Exports: []ExportEntry{
+					{Symbols: []ExportSymbol{{Original: "Foo"}}, AbsPath: "2"},
+					{Symbols: []ExportSymbol{{Original: "Bar"}}, AbsPath: "4"},
+				},
+			},
+			"3": {},
+			"4": {
+				Exports: []ExportEntry{{Symbols: []ExportSymbol{{Original: "Bar"}}, AbsPath: "4"}},
+			},
+		},
+	}
+	parser := lang.testParser()
+
+	symbols, all, err := parser.ImportedSymbols("1", "2")
+	a.NoError(err)
+	a.Equal([]string{"Foo", "Bar"}, symbols)
+	a.False(all)
+
+	symbols, all, err = parser.ImportedSymbols("1", "3")
+	a.NoError(err)
+	a.Nil(symbols)
+	a.True(all)
+
+	parser.UnwrapProxyExports = true
+	symbols, _, err = parser.ImportedSymbols("1", "2")
+	a.NoError(err)
+	a.Equal([]string{"Foo"}, symbols)
+	symbols, _, err = parser.ImportedSymbols("1", "4")
+	a.NoError(err)
+	a.Equal([]string{"Bar"}, symbols)
+}
+
+func TestParser_EdgeData(t *testing.T) {
+	a := require.New(t)
+	lang := &TestLanguage{
+		imports: map[string]*ImportsResult{
+			"1": {
+				Imports: []ImportEntry{
+					{Symbols: []string{"Foo"}, AbsPath: "2", Line: 3, TypeOnly: true},
+					{Symbols: []string{"Bar"}, AbsPath: "2", Line: 1, TypeOnly: true, Conditional: true},
+					{AbsPath: "3", Line: 5, Dynamic: true},
+					{AbsPath: "3", Line: 7},
+				},
+			},
+		},
+		exports: map[string]*ExportsResult{
+			"1": {
+				Exports: []ExportEntry{{Symbols: []ExportSymbol{{Original: "Baz"}}, AbsPath: "4"}},
+			},
+			"2": {},
+			"3": {},
+			"4": {},
+		},
+	}
+	parser := lang.testParser()
+
+	a.Equal(&Dependency{
+		Symbols:  []string{"Foo", "Bar"},
+		Lines:    []int{1, 3},
+		TypeOnly: true,
+		imports:  2,
+	}, parser.EdgeData("1", "2"))
+	a.Equal(&Dependency{Lines: []int{5, 7}, imports: 2}, parser.EdgeData("1", "3"))
+	a.Equal(&Dependency{Symbols: []string{"Baz"}, ReExport: true, imports: 1}, parser.EdgeData("1", "4"))
+	a.Nil(parser.EdgeData("1", "5"))
+
+	dep := parser.EdgeData("1", "2").(*Dependency)
+	a.Equal(1, dep.Line())
+	a.Equal([]string{"type-only"}, dep.Kinds())
+}
+
+func TestParser_IncludeExternal(t *testing.T) {
+	a := require.New(t)
+	lang := &TestLanguage{
+		imports: map[string]*ImportsResult{
+			"1": {
+				Imports: []ImportEntry{
+					{AbsPath: "2"},
+					ExternalImport(NpmEcosystem, "react", "useState"),
+				},
+			},
+			"2": {},
+		},
+		exports: map[string]*ExportsResult{
+			"1": {},
+			"2": {},
+		},
+	}
+	parser := lang.testParser()
+
+	node, err := parser.Node("1")
+	a.NoError(err)
+	deps, err := parser.Deps(node)
+	a.NoError(err)
+	a.Len(deps, 1)
+	a.Equal("2", deps[0].Id)
+
+	parser.IncludeExternal = true
+	deps, err = parser.Deps(node)
+	a.NoError(err)
+	a.Len(deps, 2)
+	a.Equal("npm:react", deps[1].Id)
+	a.Equal("npm:react", deps[1].Data.RelPath)
+	deps, err = parser.Deps(deps[1])
+	a.NoError(err)
+	a.Empty(deps)
+}
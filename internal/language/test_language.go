@@ -3,6 +3,8 @@ package language
 import (
 	"errors"
 	"time"
+
+	"github.com/gabotechs/dep-tree/internal/utils"
 )
 
 type TestFileContent struct {
@@ -17,9 +19,10 @@ type TestLanguage struct {
 func (t *TestLanguage) testParser() *Parser {
 	return &Parser{
 		Lang:         t,
-		FileCache:    map[string]*FileInfo{},
-		ImportsCache: map[string]*ImportsResult{},
-		ExportsCache: map[string]*ExportEntries{},
+		FileCache:    utils.NewCache[string, *FileInfo](),
+		ImportsCache: utils.NewCache[string, *ImportsResult](),
+		ExportsCache: utils.NewCache[string, *ExportEntries](),
+		DepsCache:    utils.NewCache[string, *resolvedImports](),
 	}
 }
 
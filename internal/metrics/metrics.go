@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// Metrics are the package metrics described by Robert C. Martin, computed for a group of files.
+type Metrics struct {
+	Group string `json:"group"`
+	Files int    `json:"files"`
+	// Afferent (Ca) is the amount of files outside the group that depend on files inside it.
+	Afferent int `json:"afferent"`
+	// Efferent (Ce) is the amount of files outside the group that files inside it depend on.
+	Efferent int `json:"efferent"`
+	// Instability (I) is Ce / (Ca + Ce). Groups that are not coupled to anything are
+	// considered stable.
+	Instability float64 `json:"instability"`
+	// Abstractness (A) is the ratio of abstract types over all the exported types in the
+	// group. It is nil if the language cannot tell or the group does not export any type.
+	Abstractness *float64 `json:"abstractness"`
+	// Distance (D) from the main sequence, |A + I - 1|. It is nil if Abstractness is.
+	Distance *float64 `json:"distance"`
+}
+
+// Zone returns where a group that is far from the main sequence ends up: the zone of pain,
+// for stable and concrete groups, or the zone of uselessness, for unstable and abstract ones.
+func (m *Metrics) Zone() string {
+	if m.Abstractness == nil || *m.Abstractness+m.Instability < 1 {
+		return "zone of pain"
+	}
+	return "zone of uselessness"
+}
+
+// Compute calculates the metrics for every group of files in the graph, sorted by group name.
+func Compute(g *graph.Graph[*language.FileInfo], groupBy language.GroupBy) []Metrics {
+	type groupAcc struct {
+		files         int
+		types         int
+		abstractTypes int
+		afferent      map[string]bool
+		efferent      map[string]bool
+	}
+	groups := map[string]*groupAcc{}
+	groupOf := map[string]string{}
+	for _, node := range g.AllNodes() {
+		group := groupBy(node.Data)
+		groupOf[node.Id] = group
+		acc, ok := groups[group]
+		if !ok {
+			acc = &groupAcc{afferent: map[string]bool{}, efferent: map[string]bool{}}
+			groups[group] = acc
+		}
+		acc.files++
+		acc.types += node.Data.Types
+		acc.abstractTypes += node.Data.AbstractTypes
+	}
+
+	for _, node := range g.AllNodes() {
+		for _, dep := range g.FromId(node.Id) {
+			from, to := groupOf[node.Id], groupOf[dep.Id]
+			if from == to {
+				continue
+			}
+			groups[from].efferent[dep.Id] = true
+			groups[to].afferent[node.Id] = true
+		}
+	}
+
+	result := make([]Metrics, 0, len(groups))
+	for group, acc := range groups {
+		m := Metrics{
+			Group:    group,
+			Files:    acc.files,
+			Afferent: len(acc.afferent),
+			Efferent: len(acc.efferent),
+		}
+		if m.Afferent+m.Efferent > 0 {
+			m.Instability = float64(m.Efferent) / float64(m.Afferent+m.Efferent)
+		}
+		if acc.types > 0 {
+			abstractness := float64(acc.abstractTypes) / float64(acc.types)
+			distance := math.Abs(abstractness + m.Instability - 1)
+			m.Abstractness, m.Distance = &abstractness, &distance
+		}
+		result = append(result, m)
+	}
+	slices.SortFunc(result, func(a, b Metrics) int { return strings.Compare(a.Group, b.Group) })
+	return result
+}
+
+// MaxDistanceRule reports the groups that are further than maxDistance from the main sequence.
+// Groups with unknown abstractness are not taken into account.
+func MaxDistanceRule(maxDistance float64, groupBy language.GroupBy) func(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+	return func(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+		var violations []string
+		for _, m := range Compute(g, groupBy) {
+			if m.Distance != nil && *m.Distance > maxDistance {
+				violations = append(violations, fmt.Sprintf(
+					"%s is in the %s (D=%.2f, I=%.2f, A=%.2f)",
+					m.Group, m.Zone(), *m.Distance, m.Instability, *m.Abstractness,
+				))
+			}
+		}
+		return violations, nil
+	}
+}
@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// makeGraph builds a graph where every file declares types and abstractTypes.
+func makeGraph(files map[string][2]int, deps map[string][]string) *graph.Graph[*language.FileInfo] {
+	g := graph.NewGraph[*language.FileInfo]()
+	for _, path := range []string{"app/main.go", "app/cli.go", "domain/user.go", "domain/repo.go", "db/sql.go"} {
+		if types, ok := files[path]; ok {
+			g.AddNode(graph.MakeNode(path, &language.FileInfo{RelPath: path, Types: types[0], AbstractTypes: types[1]}))
+		}
+	}
+	for from, tos := range deps {
+		_ = g.AddFromToEdge(from, tos...)
+	}
+	return g
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+func TestCompute(t *testing.T) {
+	a := require.New(t)
+	g := makeGraph(
+		map[string][2]int{
+			"app/main.go":    {0, 0},
+			"app/cli.go":     {0, 0},
+			"domain/user.go": {1, 0},
+			"domain/repo.go": {1, 1},
+			"db/sql.go":      {2, 0},
+		},
+		map[string][]string{
+			"app/main.go":    {"app/cli.go", "domain/user.go", "db/sql.go"},
+			"app/cli.go":     {"domain/repo.go"},
+			"domain/user.go": {"domain/repo.go"},
+			"db/sql.go":      {"domain/repo.go"},
+		},
+	)
+	groupBy, err := language.ParseGroupBy("dir")
+	a.NoError(err)
+
+	a.Equal([]Metrics{
+		{
+			Group:       "app",
+			Files:       2,
+			Afferent:    0,
+			Efferent:    3,
+			Instability: 1,
+		},
+		{
+			Group:        "db",
+			Files:        1,
+			Afferent:     1,
+			Efferent:     1,
+			Instability:  0.5,
+			Abstractness: ptr(0),
+			Distance:     ptr(0.5),
+		},
+		{
+			Group:        "domain",
+			Files:        2,
+			Afferent:     3,
+			Efferent:     0,
+			Instability:  0,
+			Abstractness: ptr(0.5),
+			Distance:     ptr(0.5),
+		},
+	}, Compute(g, groupBy))
+}
+
+func TestMaxDistanceRule(t *testing.T) {
+	a := require.New(t)
+	g := makeGraph(
+		map[string][2]int{
+			"app/main.go":    {2, 2},
+			"domain/user.go": {1, 0},
+			"db/sql.go":      {1, 0},
+		},
+		map[string][]string{
+			"app/main.go":    {"domain/user.go"},
+			"domain/user.go": {"db/sql.go"},
+		},
+	)
+	groupBy, err := language.ParseGroupBy("dir")
+	a.NoError(err)
+
+	violations, err := MaxDistanceRule(0.6, groupBy)(g)
+	a.NoError(err)
+	a.Equal([]string{
+		"app is in the zone of uselessness (D=1.00, I=1.00, A=1.00)",
+		"db is in the zone of pain (D=1.00, I=0.00, A=0.00)",
+	}, violations)
+}
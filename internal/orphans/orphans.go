@@ -0,0 +1,60 @@
+package orphans
+
+import (
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+// Report holds the files that the entrypoints do not need.
+type Report struct {
+	// Unreachable are the files that are reached neither from the entrypoints nor from tests.
+	Unreachable []string
+	// TestOnly are the files that are only reached from tests.
+	TestOnly []string
+}
+
+// Find looks for the files in scanned that cannot be reached from entrypoints in g, which must
+// have been loaded out of both the entrypoints and the tests. Tests themselves are never
+// reported, and the result keeps the order of scanned.
+func Find[T any](g *graph.Graph[T], scanned []string, entrypoints []string, tests []string) Report {
+	fromEntrypoints := reachable(g, entrypoints)
+	fromTests := reachable(g, tests)
+	isTest := make(map[string]bool, len(tests))
+	for _, test := range tests {
+		isTest[test] = true
+	}
+
+	var report Report
+	for _, file := range scanned {
+		switch {
+		case isTest[file] || fromEntrypoints[file]:
+		case fromTests[file]:
+			report.TestOnly = append(report.TestOnly, file)
+		default:
+			report.Unreachable = append(report.Unreachable, file)
+		}
+	}
+	return report
+}
+
+// reachable returns the ids of the nodes that can be reached from ids, including themselves.
+func reachable[T any](g *graph.Graph[T], ids []string) map[string]bool {
+	visited := make(map[string]bool)
+	var stack []string
+	for _, id := range ids {
+		if g.Has(id) {
+			stack = append(stack, id)
+		}
+	}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		for _, dep := range g.FromId(id) {
+			stack = append(stack, dep.Id)
+		}
+	}
+	return visited
+}
@@ -0,0 +1,29 @@
+package orphans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/graph"
+)
+
+func TestFind(t *testing.T) {
+	a := require.New(t)
+	g := graph.NewGraph[int]()
+	for i, id := range []string{"main", "a", "b", "a_test", "helper", "b_test"} {
+		g.AddNode(graph.MakeNode(id, i))
+	}
+	a.NoError(g.AddFromToEdge("main", "a"))
+	a.NoError(g.AddFromToEdge("a_test", "a", "helper"))
+	a.NoError(g.AddFromToEdge("b_test", "b"))
+
+	report := Find(
+		g,
+		[]string{"main", "a", "b", "c", "a_test", "b_test", "helper", "unused"},
+		[]string{"main"},
+		[]string{"a_test", "b_test"},
+	)
+	a.Equal([]string{"b", "helper"}, report.TestOnly)
+	a.Equal([]string{"c", "unused"}, report.Unreachable)
+}
@@ -0,0 +1,81 @@
+package owners
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gabotechs/dep-tree/internal/check"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+// Crossing is a dependency between files that do not share any owner.
+type Crossing struct {
+	From       *graph.Node[*language.FileInfo]
+	To         *graph.Node[*language.FileInfo]
+	FromOwners []string
+	ToOwners   []string
+	// Line is the line in From where To is imported, or 0 if it is not known.
+	Line int
+}
+
+// Crossings returns the dependencies in g between files that are owned by different owners.
+// Dependencies from or to files that are not owned by anyone are not taken into account.
+func (c *CodeOwners) Crossings(g *graph.Graph[*language.FileInfo]) []Crossing {
+	var result []Crossing
+	for _, node := range g.AllNodes() {
+		fromOwners := c.Of(node.Data.AbsPath)
+		if len(fromOwners) == 0 {
+			continue
+		}
+		for _, dep := range g.FromId(node.Id) {
+			toOwners := c.Of(dep.Data.AbsPath)
+			if len(toOwners) == 0 || slices.ContainsFunc(fromOwners, func(owner string) bool {
+				return slices.Contains(toOwners, owner)
+			}) {
+				continue
+			}
+			crossing := Crossing{From: node, To: dep, FromOwners: fromOwners, ToOwners: toOwners}
+			if data, ok := g.EdgeData(node.Id, dep.Id).(graph.LineData); ok {
+				crossing.Line = data.Line()
+			}
+			result = append(result, crossing)
+		}
+	}
+	return result
+}
+
+// Rule reports the dependencies across owners that break rules, which are keyed by the owner
+// of the files that depend on others.
+func (c *CodeOwners) Rule(
+	rules map[string]check.OwnersRule,
+	display func(node *graph.Node[*language.FileInfo]) string,
+) func(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+	return func(g *graph.Graph[*language.FileInfo]) ([]string, error) {
+		var violations []string
+		for _, crossing := range c.Crossings(g) {
+			for _, owner := range crossing.FromOwners {
+				r, ok := rules[owner]
+				if !ok || r.Allows(crossing.ToOwners) {
+					continue
+				}
+				from := display(crossing.From)
+				if crossing.Line > 0 {
+					from += fmt.Sprintf(":%d", crossing.Line)
+				}
+				violation := fmt.Sprintf(
+					"%s -> %s (%s -> %s)",
+					from, display(crossing.To),
+					strings.Join(crossing.FromOwners, " "), strings.Join(crossing.ToOwners, " "),
+				)
+				if r.Reason != "" {
+					violation += "\n" + r.Reason
+				}
+				violations = append(violations, violation)
+				break
+			}
+		}
+		return violations, nil
+	}
+}
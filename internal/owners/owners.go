@@ -0,0 +1,129 @@
+package owners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// locations are the places where a CODEOWNERS file is looked for, in order of precedence,
+// relative to the root of the repository.
+var locations = []string{filepath.Join(".github", "CODEOWNERS"), "CODEOWNERS", filepath.Join("docs", "CODEOWNERS")}
+
+type rule struct {
+	// patterns match the files owned by owners, any of them is enough.
+	patterns []string
+	owners   []string
+}
+
+// CodeOwners tells who owns each file in a repository, as declared in a CODEOWNERS file.
+type CodeOwners struct {
+	// Root is the directory to which the patterns in the CODEOWNERS file are relative.
+	Root  string
+	rules []rule
+}
+
+// Find looks for a CODEOWNERS file in the usual locations of dir and of its parent
+// directories. It returns nil if there is none.
+func Find(dir string) (*CodeOwners, error) {
+	for {
+		for _, location := range locations {
+			if _, err := os.Stat(filepath.Join(dir, location)); err == nil {
+				return Load(filepath.Join(dir, location))
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads the CODEOWNERS file in path. Files in .github or docs directories are relative to
+// the parent directory, like in GitHub.
+func Load(path string) (*CodeOwners, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	root := filepath.Dir(path)
+	if base := filepath.Base(root); base == ".github" || base == "docs" {
+		root = filepath.Dir(root)
+	}
+
+	result := CodeOwners{Root: root}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		patterns, err := translate(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern in %s:%d: %w", path, line, err)
+		}
+		result.rules = append(result.rules, rule{patterns: patterns, owners: owners})
+	}
+	return &result, scanner.Err()
+}
+
+// translate converts a CODEOWNERS pattern, which follows the gitignore syntax, into globstar
+// patterns relative to the root of the repository.
+func translate(pattern string) ([]string, error) {
+	p := strings.TrimSuffix(pattern, "/")
+	onlyDirs := p != pattern
+	// Patterns with a slash at the beginning or in the middle are relative to the root.
+	if strings.Contains(p, "/") {
+		p = strings.TrimPrefix(p, "/")
+	} else {
+		p = "**/" + p
+	}
+	if !doublestar.ValidatePattern(p) {
+		return nil, errors.New(pattern)
+	}
+	// Matching a directory means owning every file in it, except for a trailing /*, which
+	// only matches the files directly inside the directory.
+	if onlyDirs {
+		return []string{p + "/**"}, nil
+	} else if path.Base(p) == "*" {
+		return []string{p}, nil
+	}
+	return []string{p, p + "/**"}, nil
+}
+
+// Of returns the owners of the file at absPath, which are the ones of the last matching rule.
+// Files that are not owned by anyone, or that are outside Root, have no owners.
+func (c *CodeOwners) Of(absPath string) []string {
+	rel, err := filepath.Rel(c.Root, absPath)
+	if err != nil || !filepath.IsLocal(rel) {
+		return nil
+	}
+	rel = filepath.ToSlash(rel)
+	for i := len(c.rules) - 1; i >= 0; i-- {
+		for _, pattern := range c.rules[i].patterns {
+			if ok, _ := doublestar.Match(pattern, rel); ok {
+				return c.rules[i].owners
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package owners
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gabotechs/dep-tree/internal/check"
+	"github.com/gabotechs/dep-tree/internal/graph"
+	"github.com/gabotechs/dep-tree/internal/language"
+)
+
+const testFolder = ".owners_test"
+
+func TestFind(t *testing.T) {
+	a := require.New(t)
+	root, _ := filepath.Abs(testFolder)
+
+	codeOwners, err := Find(filepath.Join(root, "billing", "internal"))
+	a.NoError(err)
+	a.Equal(root, codeOwners.Root)
+
+	codeOwners, err = Find(t.TempDir())
+	a.NoError(err)
+	a.Nil(codeOwners)
+}
+
+func TestCodeOwners_Of(t *testing.T) {
+	root, _ := filepath.Abs(testFolder)
+	codeOwners, err := Load(filepath.Join(root, ".github", "CODEOWNERS"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		File     string
+		Expected []string
+	}{
+		{File: "main.py", Expected: []string{"@platform"}},
+		{File: "billing/api.py", Expected: []string{"@team-billing"}},
+		{File: "billing/internal/db.py", Expected: []string{"@team-billing"}},
+		{File: "billing/generated.py"},
+		{File: "billing/README.md", Expected: []string{"@docs"}},
+		{File: "users/api.py", Expected: []string{"@team-users", "@org/reviewers"}},
+		{File: "src/users/api.py", Expected: []string{"@team-users", "@org/reviewers"}},
+		{File: "docs/index.html", Expected: []string{"@docs"}},
+		{File: "docs/api/index.html", Expected: []string{"@platform"}},
+		{File: "../outside.py"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.File, func(t *testing.T) {
+			require.Equal(t, tt.Expected, codeOwners.Of(filepath.Join(root, tt.File)))
+		})
+	}
+}
+
+func TestCodeOwners_Rule(t *testing.T) {
+	a := require.New(t)
+	root, _ := filepath.Abs(testFolder)
+	codeOwners, err := Load(filepath.Join(root, ".github", "CODEOWNERS"))
+	a.NoError(err)
+
+	g := graph.NewGraph[*language.FileInfo]()
+	for _, file := range []string{"main.py", "billing/api.py", "billing/generated.py", "users/api.py"} {
+		g.AddNode(graph.MakeNode(file, &language.FileInfo{AbsPath: filepath.Join(root, file), RelPath: file}))
+	}
+	a.NoError(g.AddFromToEdge("main.py", "billing/api.py", "users/api.py"))
+	a.NoError(g.AddFromToEdge("billing/api.py", "users/api.py", "billing/generated.py"))
+	a.NoError(g.AddFromToEdge("users/api.py", "billing/api.py"))
+
+	var crossings [][2]string
+	for _, crossing := range codeOwners.Crossings(g) {
+		crossings = append(crossings, [2]string{crossing.From.Id, crossing.To.Id})
+	}
+	a.Equal([][2]string{
+		{"main.py", "billing/api.py"},
+		{"main.py", "users/api.py"},
+		{"billing/api.py", "users/api.py"},
+		{"users/api.py", "billing/api.py"},
+	}, crossings)
+
+	rule := codeOwners.Rule(map[string]check.OwnersRule{
+		"@team-billing":  {Deny: []string{"@team-users"}, Reason: "Billing is independent from users"},
+		"@org/reviewers": {Allow: []string{"@platform"}},
+	}, func(node *graph.Node[*language.FileInfo]) string { return node.Data.RelPath })
+	violations, err := rule(g)
+	a.NoError(err)
+	a.Equal([]string{
+		"billing/api.py -> users/api.py (@team-billing -> @team-users @org/reviewers)\nBilling is independent from users",
+		"users/api.py -> billing/api.py (@team-users @org/reviewers -> @team-billing)",
+	}, violations)
+}
@@ -14,3 +14,5 @@ from . import un_existing  # ignored (should error?)
 from src.module import *
 from src.module import module
 from src.module import bar
+import requests
+from yaml import safe_load, dump
@@ -15,6 +15,9 @@ func (l *Language) handleImport(imp *python_grammar.Import, currDir string) []la
 	}
 	resolved := l.ResolveAbsolute(imp.Path[0:], currDir)
 	if resolved == nil {
+		if pkg := pypiPackage(imp.Path); pkg != "" {
+			return []language.ImportEntry{language.ExternalImport(language.PypiEcosystem, pkg)}
+		}
 		return nil
 	}
 	switch {
@@ -112,20 +115,32 @@ func (l *Language) handleFromImport(imp *python_grammar.FromImport, currDir stri
 	}
 
 	resolved, err := l.resolveFromImportPath(imp, currDir)
-	if err != nil || resolved == nil {
+	if err != nil {
 		return nil, err
 	}
+	if resolved == nil {
+		if pkg := pypiPackage(imp.Path); pkg != "" && len(imp.Relative) == 0 {
+			if imp.All {
+				return []language.ImportEntry{language.AllImport(language.ExternalId(language.PypiEcosystem, pkg))}, nil
+			}
+			return []language.ImportEntry{language.ExternalImport(language.PypiEcosystem, pkg, importedNames(imp)...)}, nil
+		}
+		return nil, nil
+	}
 
 	if imp.All {
 		return handleFromImportAll(resolved), nil
 	} else {
-		names := make([]string, len(imp.Names))
-		for i, name := range imp.Names {
-			names[i] = name.Name
-		}
+		return handleFromImportNames(resolved, importedNames(imp))
+	}
+}
 
-		return handleFromImportNames(resolved, names)
+func importedNames(imp *python_grammar.FromImport) []string {
+	names := make([]string, len(imp.Names))
+	for i, name := range imp.Names {
+		names[i] = name.Name
 	}
+	return names
 }
 
 func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResult, error) {
@@ -138,10 +153,14 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 		case stmt == nil:
 			// Is this even possible?
 		case stmt.Import != nil:
-			imports = append(imports, l.handleImport(stmt.Import, filepath.Dir(file.AbsPath))...)
+			imports = append(imports, withPosition(
+				l.handleImport(stmt.Import, filepath.Dir(file.AbsPath)),
+				stmt.Pos.Line,
+				stmt.Import.Indented,
+			)...)
 		case stmt.FromImport != nil:
 			newImports, err := l.handleFromImport(stmt.FromImport, filepath.Dir(file.AbsPath))
-			imports = append(imports, newImports...)
+			imports = append(imports, withPosition(newImports, stmt.Pos.Line, stmt.FromImport.Indented)...)
 			if err != nil {
 				errors = append(errors, err)
 			}
@@ -150,6 +169,16 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 	return &language.ImportsResult{Imports: imports, Errors: errors}, nil
 }
 
+// withPosition sets where in the file the imports come from. Indented imports are considered
+// conditional, as they are usually inside an if or a try statement.
+func withPosition(imports []language.ImportEntry, line int, indented bool) []language.ImportEntry {
+	for i := range imports {
+		imports[i].Line = line
+		imports[i].Conditional = indented
+	}
+	return imports
+}
+
 func (l *Language) resolveFromImportPath(imp *python_grammar.FromImport, currDir string) (*ResolveResult, error) {
 	if len(imp.Relative) > 0 {
 		return ResolveRelative(imp.Path, currDir, len(imp.Relative)-1)
@@ -12,6 +12,16 @@ import (
 
 const importsTestFolder = ".imports_test"
 
+func at(line int, entry language.ImportEntry) language.ImportEntry {
+	entry.Line = line
+	return entry
+}
+
+func conditionalAt(line int, entry language.ImportEntry) language.ImportEntry {
+	entry.Conditional = true
+	return at(line, entry)
+}
+
 func TestLanguage_ParseImports(t *testing.T) {
 	importsTestFolder, _ := filepath.Abs(importsTestFolder)
 
@@ -28,16 +38,18 @@ func TestLanguage_ParseImports(t *testing.T) {
 			File:       "main.py",
 			Entrypoint: "main.py",
 			Expected: []language.ImportEntry{
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "foo.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py")),
-				language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py")),
-				language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py")),
-				language.AllImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "module.py")),
-				language.SymbolsImport([]string{"bar"}, filepath.Join(importsTestFolder, "src", "module", "__init__.py")),
+				at(1, language.EmptyImport(filepath.Join(importsTestFolder, "src", "foo.py"))),
+				at(1, language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py"))),
+				at(2, language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py"))),
+				at(3, language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py"))),
+				at(6, language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py"))),
+				conditionalAt(9, language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py"))),
+				conditionalAt(11, language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py"))),
+				at(14, language.AllImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py"))),
+				at(15, language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "module.py"))),
+				at(16, language.SymbolsImport([]string{"bar"}, filepath.Join(importsTestFolder, "src", "module", "__init__.py"))),
+				at(17, language.ExternalImport(language.PypiEcosystem, "requests")),
+				at(18, language.ExternalImport(language.PypiEcosystem, "yaml", "safe_load", "dump")),
 			},
 			ExpectedErrors: []string{
 				"cannot import file src.py from directory",
@@ -50,16 +62,18 @@ func TestLanguage_ParseImports(t *testing.T) {
 			Entrypoint:                "main.py",
 			ExcludeConditionalImports: true,
 			Expected: []language.ImportEntry{
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "foo.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py")),
+				at(1, language.EmptyImport(filepath.Join(importsTestFolder, "src", "foo.py"))),
+				at(1, language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py"))),
+				at(2, language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py"))),
+				at(3, language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py"))),
 				// language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py")),
 				// language.EmptyImport(filepath.Join(importsTestFolder, "src", "main.py")),
-				language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py")),
-				language.AllImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py")),
-				language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "module.py")),
-				language.SymbolsImport([]string{"bar"}, filepath.Join(importsTestFolder, "src", "module", "__init__.py")),
+				at(6, language.SymbolsImport([]string{"main"}, filepath.Join(importsTestFolder, "src", "main.py"))),
+				at(14, language.AllImport(filepath.Join(importsTestFolder, "src", "module", "__init__.py"))),
+				at(15, language.EmptyImport(filepath.Join(importsTestFolder, "src", "module", "module.py"))),
+				at(16, language.SymbolsImport([]string{"bar"}, filepath.Join(importsTestFolder, "src", "module", "__init__.py"))),
+				at(17, language.ExternalImport(language.PypiEcosystem, "requests")),
+				at(18, language.ExternalImport(language.PypiEcosystem, "yaml", "safe_load", "dump")),
 			},
 			ExpectedErrors: []string{
 				"cannot import file src.py from directory",
@@ -11,6 +11,7 @@ import (
 )
 
 type Statement struct {
+	Pos lexer.Position
 	// imports.
 	FromImport *FromImport `@@ |`
 	Import     *Import     `@@ |`
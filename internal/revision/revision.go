@@ -0,0 +1,248 @@
+package revision
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Repository is a git repository from which the files at any revision can be read, without
+// touching its working tree.
+type Repository struct {
+	repo *git.Repository
+	// Root is the directory where the working tree of the repository is.
+	Root string
+}
+
+// Open opens the git repository that contains path.
+func Open(path string) (*Repository, error) {
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("could not open a git repository in %s: %w", path, err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{repo: repo, Root: worktree.Filesystem.Root()}, nil
+}
+
+// commit resolves a revision, like a branch, a tag or a commit hash, into a commit.
+func (r *Repository) commit(rev string) (*object.Commit, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve revision %s: %w", rev, err)
+	}
+	commit, err := r.repo.CommitObject(*hash)
+	if err == nil {
+		return commit, nil
+	}
+	// Annotated tags resolve to the tag object instead of to the commit.
+	tag, tagErr := r.repo.TagObject(*hash)
+	if tagErr != nil {
+		return nil, fmt.Errorf("revision %s is not a commit: %w", rev, err)
+	}
+	return tag.Commit()
+}
+
+// Extract writes the files in the repository at rev into dir, reading them from the git objects.
+// If dirs, which are absolute paths in the working tree, are given, only the files inside them
+// are written, together with the ones directly inside their parents, where configuration files
+// like package.json or Cargo.toml might be.
+func (r *Repository) Extract(rev string, dir string, dirs ...string) error {
+	commit, err := r.commit(rev)
+	if err != nil {
+		return err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return err
+	}
+	all := len(dirs) == 0
+	var prefixes []string
+	parents := map[string]bool{}
+	for _, d := range dirs {
+		rel, err := filepath.Rel(r.Root, d)
+		if err != nil || rel == "." {
+			all = true
+			break
+		} else if !filepath.IsLocal(rel) {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+		prefixes = append(prefixes, rel+"/")
+		for parent := path.Dir(rel); !parents[parent]; parent = path.Dir(parent) {
+			parents[parent] = true
+		}
+	}
+	wanted := func(name string) bool {
+		return all || parents[path.Dir(name)] || slices.ContainsFunc(prefixes, func(prefix string) bool {
+			return strings.HasPrefix(name, prefix)
+		})
+	}
+	return tree.Files().ForEach(func(file *object.File) error {
+		if !wanted(file.Name) {
+			return nil
+		}
+		path := filepath.Join(dir, filepath.FromSlash(file.Name))
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			return err
+		}
+		if file.Mode == filemode.Symlink {
+			target, err := file.Contents()
+			if err != nil {
+				return err
+			}
+			return os.Symlink(target, path)
+		}
+		perm := os.FileMode(0o644)
+		if file.Mode == filemode.Executable {
+			perm = 0o755
+		}
+		return writeFile(file, path, perm)
+	})
+}
+
+// ChangeSets returns the files changed by each of the last n commits reachable from HEAD, as
+// absolute paths in the working tree. Merge commits are skipped, as their changes are already
+// part of the commits being merged.
+func (r *Repository) ChangeSets(n int) ([][]string, error) {
+	var result [][]string
+	err := r.walk(n, time.Time{}, func(commit *object.Commit) error {
+		changed, err := r.changedFiles(commit)
+		if err != nil {
+			return fmt.Errorf("could not compute the changes of commit %s: %w", commit.Hash, err)
+		}
+		result = append(result, changed)
+		return nil
+	})
+	return result, err
+}
+
+// Churn is how much a file changed in the git history.
+type Churn struct {
+	// Commits is the amount of commits that changed the file.
+	Commits int
+	// Additions and Deletions are the amount of lines added and removed by those commits.
+	Additions int
+	Deletions int
+}
+
+// Churn returns how much each file changed in the last n commits reachable from HEAD, only
+// taking into account the ones made after since if it is not zero. Files are keyed by their
+// absolute path in the working tree, and merge commits are skipped like in ChangeSets.
+func (r *Repository) Churn(n int, since time.Time) (map[string]Churn, error) {
+	result := map[string]Churn{}
+	err := r.walk(n, since, func(commit *object.Commit) error {
+		stats, err := commit.Stats()
+		if err != nil {
+			return fmt.Errorf("could not compute the changes of commit %s: %w", commit.Hash, err)
+		}
+		for _, stat := range stats {
+			path := filepath.Join(r.Root, filepath.FromSlash(stat.Name))
+			churn := result[path]
+			churn.Commits++
+			churn.Additions += stat.Addition
+			churn.Deletions += stat.Deletion
+			result[path] = churn
+		}
+		return nil
+	})
+	return result, err
+}
+
+// walk calls f with each of the last n commits reachable from HEAD that are not merges, newest
+// first, stopping at the first one made before since if it is not zero.
+func (r *Repository) walk(n int, since time.Time, f func(commit *object.Commit) error) error {
+	head, err := r.commit("HEAD")
+	if err != nil {
+		return err
+	}
+	opts := &git.LogOptions{From: head.Hash}
+	if !since.IsZero() {
+		opts.Since = &since
+	}
+	iter, err := r.repo.Log(opts)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for visited := 0; visited < n; {
+		commit, err := iter.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if commit.NumParents() > 1 {
+			continue
+		}
+		if err = f(commit); err != nil {
+			return err
+		}
+		visited++
+	}
+	return nil
+}
+
+// changedFiles returns the sorted absolute paths of the files changed by a commit with at most
+// one parent.
+func (r *Repository) changedFiles(commit *object.Commit) ([]string, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	// The first commit changes every file in it.
+	parentTree := &object.Tree{}
+	if commit.NumParents() == 1 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := parentTree.Diff(tree)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, change := range changes {
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" {
+				result = append(result, filepath.Join(r.Root, filepath.FromSlash(name)))
+			}
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result), nil
+}
+
+func writeFile(file *object.File, path string, perm os.FileMode) error {
+	reader, err := file.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, reader)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
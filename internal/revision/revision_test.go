@@ -0,0 +1,117 @@
+package revision
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func commitFiles(t *testing.T, dir string, files map[string]string) {
+	a := require.New(t)
+	repo, err := git.PlainOpen(dir)
+	a.NoError(err)
+	worktree, err := repo.Worktree()
+	a.NoError(err)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		a.NoError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		a.NoError(os.WriteFile(path, []byte(content), 0o644))
+		_, err = worktree.Add(name)
+		a.NoError(err)
+	}
+	_, err = worktree.Commit("commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@test.com", When: time.Now()},
+	})
+	a.NoError(err)
+}
+
+func TestRepository_Extract(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	a.NoError(err)
+	commitFiles(t, dir, map[string]string{"main.py": "from .a import a\n", "src/a.py": "a = 1\n", "other/b.py": "b = 1\n"})
+	commitFiles(t, dir, map[string]string{"src/a.py": "a = 2\n"})
+
+	repo, err := Open(filepath.Join(dir, "src"))
+	a.NoError(err)
+	a.Equal(dir, repo.Root)
+
+	out := t.TempDir()
+	a.NoError(repo.Extract("HEAD~1", out))
+	content, err := os.ReadFile(filepath.Join(out, "src", "a.py"))
+	a.NoError(err)
+	a.Equal("a = 1\n", string(content))
+	content, err = os.ReadFile(filepath.Join(out, "main.py"))
+	a.NoError(err)
+	a.Equal("from .a import a\n", string(content))
+	a.FileExists(filepath.Join(out, "other", "b.py"))
+
+	// Only the files in src and directly in its parents are written.
+	out = t.TempDir()
+	a.NoError(repo.Extract("HEAD~1", out, filepath.Join(dir, "src")))
+	a.FileExists(filepath.Join(out, "src", "a.py"))
+	a.FileExists(filepath.Join(out, "main.py"))
+	a.NoFileExists(filepath.Join(out, "other", "b.py"))
+
+	a.ErrorContains(repo.Extract("unknown", t.TempDir()), "could not resolve revision unknown")
+}
+
+func TestRepository_ChangeSets(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	a.NoError(err)
+	commitFiles(t, dir, map[string]string{"main.py": "from .a import a\n", "src/a.py": "a = 1\n"})
+	commitFiles(t, dir, map[string]string{"src/a.py": "a = 2\n", "src/b.py": "b = 1\n"})
+	commitFiles(t, dir, map[string]string{"main.py": "from .b import b\n"})
+
+	repo, err := Open(dir)
+	a.NoError(err)
+
+	changeSets, err := repo.ChangeSets(10)
+	a.NoError(err)
+	a.Equal([][]string{
+		{filepath.Join(dir, "main.py")},
+		{filepath.Join(dir, "src", "a.py"), filepath.Join(dir, "src", "b.py")},
+		{filepath.Join(dir, "main.py"), filepath.Join(dir, "src", "a.py")},
+	}, changeSets)
+
+	changeSets, err = repo.ChangeSets(2)
+	a.NoError(err)
+	a.Len(changeSets, 2)
+}
+
+func TestRepository_Churn(t *testing.T) {
+	a := require.New(t)
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	a.NoError(err)
+	commitFiles(t, dir, map[string]string{"main.py": "from .a import a\n", "src/a.py": "a = 1\n"})
+	commitFiles(t, dir, map[string]string{"src/a.py": "a = 2\nb = 3\n"})
+
+	repo, err := Open(dir)
+	a.NoError(err)
+
+	churn, err := repo.Churn(10, time.Time{})
+	a.NoError(err)
+	a.Equal(map[string]Churn{
+		filepath.Join(dir, "main.py"):     {Commits: 1, Additions: 1},
+		filepath.Join(dir, "src", "a.py"): {Commits: 2, Additions: 3, Deletions: 1},
+	}, churn)
+
+	churn, err = repo.Churn(1, time.Time{})
+	a.NoError(err)
+	a.Equal(map[string]Churn{
+		filepath.Join(dir, "src", "a.py"): {Commits: 1, Additions: 2, Deletions: 1},
+	}, churn)
+
+	churn, err = repo.Churn(10, time.Now().Add(time.Hour))
+	a.NoError(err)
+	a.Empty(churn)
+}
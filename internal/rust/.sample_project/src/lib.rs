@@ -27,3 +27,5 @@ mod tests {
         run()
     }
 }
+
+use serde::Serialize;
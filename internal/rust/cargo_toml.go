@@ -3,6 +3,8 @@ package rust
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/gabotechs/dep-tree/internal/utils"
@@ -25,6 +27,9 @@ type CargoToml struct {
 	PackageDefinition packageDefinition `toml:"package"`
 	// It's [dev-]dependencies.
 	Dependencies map[string]localDependency
+	// ExternalDependencies are the names of the third-party crates in the [dev-]dependencies,
+	// as they are referenced in the code, sorted.
+	ExternalDependencies []string
 }
 
 // readCargoToml parses a Cargo.toml file given its path or to the folder where it's placed.
@@ -62,15 +67,20 @@ var readCargoToml = utils.Cached1In2Out(func(path string) (*CargoToml, error) {
 	}
 	for _, deps := range []map[string]any{decoded.DevDependencies, decoded.Dependencies} {
 		for k, v := range deps {
-			switch t := v.(type) {
-			case map[string]any:
-				switch tt := t["path"].(type) {
-				case string:
-					result.Dependencies[k] = localDependency{tt}
+			if t, ok := v.(map[string]any); ok {
+				if path, ok := t["path"].(string); ok {
+					result.Dependencies[k] = localDependency{path}
+					continue
 				}
 			}
+			// Crates with dashes in their name are referenced with underscores.
+			name := strings.ReplaceAll(k, "-", "_")
+			if !slices.Contains(result.ExternalDependencies, name) {
+				result.ExternalDependencies = append(result.ExternalDependencies, name)
+			}
 		}
 	}
+	slices.Sort(result.ExternalDependencies)
 	return &result, nil
 })
 
@@ -29,6 +29,7 @@ func TestCargoToml(t *testing.T) {
 					"baz": {"../baz"},
 					"foo": {"../foo"},
 				},
+				ExternalDependencies: []string{"mod1", "mod2", "mod3", "mod4", "mod5"},
 			},
 		},
 	}
@@ -22,18 +22,26 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 					errors = append(errors, fmt.Errorf("error resolving use statement for name %s: %w", use.Name.Original, err))
 					continue
 				} else if id == "" {
-					continue
+					crate, err := externalCrate(use.PathSlices, file.AbsPath)
+					if err != nil || crate == "" {
+						continue
+					}
+					id = language.ExternalId(language.CrateEcosystem, crate)
 				}
 
 				if use.All {
 					imports = append(imports, language.ImportEntry{
-						All:     use.All,
-						AbsPath: id,
+						All:      use.All,
+						AbsPath:  id,
+						Line:     stmt.Pos.Line,
+						ReExport: use.Pub,
 					})
 				} else {
 					imports = append(imports, language.ImportEntry{
-						Symbols: []string{string(use.Name.Original)},
-						AbsPath: id,
+						Symbols:  []string{string(use.Name.Original)},
+						AbsPath:  id,
+						Line:     stmt.Pos.Line,
+						ReExport: use.Pub,
 					})
 				}
 			}
@@ -56,6 +64,7 @@ func (l *Language) ParseImports(file *language.FileInfo) (*language.ImportsResul
 				All:     true,
 				Symbols: names,
 				AbsPath: modPath,
+				Line:    stmt.Pos.Line,
 			})
 		}
 	}
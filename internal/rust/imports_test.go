@@ -24,50 +24,71 @@ func TestLanguage_ParseImports(t *testing.T) {
 					All:     true,
 					Symbols: []string{"sum"},
 					AbsPath: filepath.Join(absTestFolder, "src", "sum.rs"),
+					Line:    1,
 				},
 				{
 					All:     true,
 					Symbols: []string{"div"},
 					AbsPath: filepath.Join(absTestFolder, "src", "div", "mod.rs"),
+					Line:    2,
 				},
 				{
 					All:     true,
 					Symbols: []string{"avg"},
 					AbsPath: filepath.Join(absTestFolder, "src", "avg.rs"),
+					Line:    3,
 				},
 				{
 					All:     true,
 					Symbols: []string{"abs"},
 					AbsPath: filepath.Join(absTestFolder, "src", "abs.rs"),
+					Line:    4,
 				},
 				{
 					All:     true,
 					Symbols: []string{"avg_2"},
 					AbsPath: filepath.Join(absTestFolder, "src", "avg_2.rs"),
+					Line:    5,
 				},
 				{
-					Symbols: []string{"abs"},
-					AbsPath: filepath.Join(absTestFolder, "src", "abs", "abs.rs"),
+					Symbols:  []string{"abs"},
+					AbsPath:  filepath.Join(absTestFolder, "src", "abs", "abs.rs"),
+					Line:     7,
+					ReExport: true,
 				},
 				{
-					Symbols: []string{"div"},
-					AbsPath: filepath.Join(absTestFolder, "src", "div", "mod.rs"),
+					Symbols:  []string{"div"},
+					AbsPath:  filepath.Join(absTestFolder, "src", "div", "mod.rs"),
+					Line:     8,
+					ReExport: true,
 				},
 				{
-					Symbols: []string{"avg"},
-					AbsPath: filepath.Join(absTestFolder, "src", "avg_2.rs"),
+					Symbols:  []string{"avg"},
+					AbsPath:  filepath.Join(absTestFolder, "src", "avg_2.rs"),
+					Line:     9,
+					ReExport: true,
 				},
 				{
-					Symbols: []string{"sum"},
-					AbsPath: filepath.Join(absTestFolder, "src", "lib.rs"),
+					Symbols:  []string{"sum"},
+					AbsPath:  filepath.Join(absTestFolder, "src", "lib.rs"),
+					Line:     10,
+					ReExport: true,
 				},
 				{
-					All:     true,
-					AbsPath: filepath.Join(absTestFolder, "src", "sum.rs"),
+					All:      true,
+					AbsPath:  filepath.Join(absTestFolder, "src", "sum.rs"),
+					Line:     11,
+					ReExport: true,
 				},
 				{
 					Symbols: []string{"run"},
 					AbsPath: filepath.Join(absTestFolder, "src", "lib.rs"),
+					Line:    23,
+				},
+				{
+					Symbols: []string{"Serialize"},
+					AbsPath: "crate:serde",
+					Line:    31,
 				},
 			},
 		},
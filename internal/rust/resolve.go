@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -109,3 +110,19 @@ func resolve(pathSlices []string, filePath string) (string, error) {
 	}
 	return mod.Path, nil
 }
+
+// externalCrate returns the name of the third-party crate from which a `use` statement imports,
+// or an empty string if it does not import from one.
+func externalCrate(pathSlices []string, filePath string) (string, error) {
+	if len(pathSlices) == 0 {
+		return "", nil
+	}
+	cargoToml, err := findClosestCargoToml(filePath)
+	if err != nil || cargoToml == nil {
+		return "", err
+	}
+	if slices.Contains(cargoToml.ExternalDependencies, pathSlices[0]) {
+		return pathSlices[0], nil
+	}
+	return "", nil
+}
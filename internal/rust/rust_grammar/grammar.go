@@ -11,6 +11,7 @@ import (
 )
 
 type Statement struct {
+	Pos lexer.Position
 	Mod *Mod `@@`
 	Use *Use `| @@`
 	Pub *Pub `| @@`
@@ -60,10 +61,30 @@ func Parse(filePath string) (*language.FileInfo, error) {
 	if err != nil {
 		return nil, err
 	}
+	types, abstractTypes := countTypes(file)
 	return &language.FileInfo{
-		Content: file,
-		Loc:     bytes.Count(content, []byte("\n")),
-		Size:    len(content),
-		AbsPath: filePath,
+		Content:       file,
+		Loc:           bytes.Count(content, []byte("\n")),
+		Size:          len(content),
+		AbsPath:       filePath,
+		Types:         types,
+		AbstractTypes: abstractTypes,
 	}, nil
 }
+
+// countTypes counts the public type declarations, traits being the abstract ones.
+func countTypes(file *File) (types int, abstractTypes int) {
+	for _, stmt := range file.Statements {
+		if stmt.Pub == nil {
+			continue
+		}
+		switch stmt.Pub.Kind {
+		case "trait":
+			types++
+			abstractTypes++
+		case "struct", "enum", "type":
+			types++
+		}
+	}
+	return types, abstractTypes
+}
@@ -2,5 +2,6 @@
 package rust_grammar
 
 type Pub struct {
-	Name Ident `"pub"  ("(" (Ident | PathSep)* ")")? "unsafe"? "async"? ("fn" | "struct" | "trait" | "enum" | "type" | "static" | "const") @Ident`
+	Kind string `"pub"  ("(" (Ident | PathSep)* ")")? "unsafe"? "async"? @("fn" | "struct" | "trait" | "enum" | "type" | "static" | "const")`
+	Name Ident  `@Ident`
 }
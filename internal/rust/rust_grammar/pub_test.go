@@ -17,60 +17,70 @@ func TestPub(t *testing.T) {
 		{
 			Name: "pub fn my_function",
 			ExpectedPub: []Pub{{
+				Kind: "fn",
 				Name: Ident("my_function"),
 			}},
 		},
 		{
 			Name: "pub unsafe fn my_function",
 			ExpectedPub: []Pub{{
+				Kind: "fn",
 				Name: Ident("my_function"),
 			}},
 		},
 		{
 			Name: "pub trait my_trait",
 			ExpectedPub: []Pub{{
+				Kind: "trait",
 				Name: Ident("my_trait"),
 			}},
 		},
 		{
 			Name: "pub struct my_struct",
 			ExpectedPub: []Pub{{
+				Kind: "struct",
 				Name: Ident("my_struct"),
 			}},
 		},
 		{
 			Name: "pub enum my_enum",
 			ExpectedPub: []Pub{{
+				Kind: "enum",
 				Name: Ident("my_enum"),
 			}},
 		},
 		{
 			Name: "pub type my_type",
 			ExpectedPub: []Pub{{
+				Kind: "type",
 				Name: Ident("my_type"),
 			}},
 		},
 		{
 			Name: "pub(crate) fn my_function and a lot of shit after",
 			ExpectedPub: []Pub{{
+				Kind: "fn",
 				Name: Ident("my_function"),
 			}},
 		},
 		{
 			Name: "pub async fn my_function ",
 			ExpectedPub: []Pub{{
+				Kind: "fn",
 				Name: Ident("my_function"),
 			}},
 		},
 		{
 			Name: "pub static VAR",
 			ExpectedPub: []Pub{{
+				Kind: "static",
 				Name: Ident("VAR"),
 			}},
 		},
 		{
 			Name: "pub const VAR",
 			ExpectedPub: []Pub{{
+				Kind: "const",
 				Name: Ident("VAR"),
 			}},
 		},
@@ -81,6 +91,7 @@ func TestPub(t *testing.T) {
 		{
 			Name: "' pub struct MyStruct '",
 			ExpectedPub: []Pub{{
+				Kind: "struct",
 				Name: Ident("MyStruct"),
 			}},
 		},
@@ -111,3 +122,20 @@ func TestPub(t *testing.T) {
 		})
 	}
 }
+
+func TestCountTypes(t *testing.T) {
+	a := require.New(t)
+	parsed, err := parser.ParseBytes("", []byte(`
+pub trait Shape {}
+pub struct Square {}
+pub enum Color { Red }
+pub type Id = String;
+pub fn area() {}
+pub const PI: f64 = 3.14;
+struct Private {}
+`))
+	a.NoError(err)
+	types, abstractTypes := countTypes(parsed)
+	a.Equal(4, types)
+	a.Equal(1, abstractTypes)
+}
@@ -2,6 +2,8 @@ package tree
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/gabotechs/dep-tree/internal/utils"
 )
@@ -46,3 +48,39 @@ func (t *Tree[T]) longestPath(
 	stack.Pop()
 	return maxLongestPath + 1, nil
 }
+
+// LongestPath returns the ids of the nodes in the longest path from the entrypoint to the
+// provided node, both included, or nil if the node is not in the tree. As cycles are removed
+// when building the tree, the path never goes through the dependencies that close them.
+func (t *Tree[T]) LongestPath(nodeId string) ([]string, error) {
+	if t.Graph.Get(nodeId) == nil {
+		return nil, nil
+	}
+	rootId := t.entrypoint.Id
+	path := []string{nodeId}
+	for nodeId != rootId {
+		lvl, err := t.longestPath(rootId, nodeId, nil)
+		if err != nil {
+			return nil, err
+		}
+		// At least one of the parents is exactly one level above.
+		var next string
+		for _, parent := range t.Graph.ToId(nodeId) {
+			parentLvl, err := t.longestPath(rootId, parent.Id, nil)
+			if err != nil {
+				return nil, err
+			}
+			if parentLvl == lvl-1 {
+				next = parent.Id
+				break
+			}
+		}
+		if next == "" {
+			return nil, fmt.Errorf("%s is not reachable from %s", nodeId, rootId)
+		}
+		nodeId = next
+		path = append(path, nodeId)
+	}
+	slices.Reverse(path)
+	return path, nil
+}
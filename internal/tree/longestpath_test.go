@@ -118,3 +118,32 @@ func Test_longestPath(t *testing.T) {
 		})
 	}
 }
+
+func TestTree_LongestPath(t *testing.T) {
+	a := require.New(t)
+	tree, err := NewTree[[]int](
+		[]string{"0"},
+		&graph.TestParser{Spec: [][]int{
+			0: {1, 2, 4},
+			1: {3},
+			2: {3},
+			3: {4},
+			4: {1},
+		}},
+		func(node *graph.Node[[]int]) string { return node.Id },
+		nil,
+	)
+	a.NoError(err)
+
+	path, err := tree.LongestPath("4")
+	a.NoError(err)
+	a.Equal([]string{"0", "1", "3", "4"}, path)
+
+	path, err = tree.LongestPath("0")
+	a.NoError(err)
+	a.Equal([]string{"0"}, path)
+
+	path, err = tree.LongestPath("5")
+	a.NoError(err)
+	a.Nil(path)
+}
@@ -31,6 +31,7 @@ func Loop[T any](
 	display func(node *graph.Node[T]) string,
 	screen tcell.Screen,
 	isRootNavigation bool,
+	reduce bool,
 	tickChan chan bool,
 	callbacks graph.LoadCallbacks[T],
 ) error {
@@ -38,6 +39,11 @@ func Loop[T any](
 	if err != nil {
 		return err
 	}
+	if reduce {
+		if _, err = t.Graph.TransitiveReduction(); err != nil {
+			return err
+		}
+	}
 	board, err := t.Render()
 	if err != nil {
 		return err
@@ -71,7 +77,7 @@ func Loop[T any](
 		Event:            nil,
 		IsRootNavigation: isRootNavigation,
 		OnNavigate: func(s *systems.State) error {
-			return Loop[T]([]string{s.SelectedId}, parser, display, screen, false, tickChan, nil)
+			return Loop[T]([]string{s.SelectedId}, parser, display, screen, false, reduce, tickChan, nil)
 		},
 	}
 
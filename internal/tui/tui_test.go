@@ -156,6 +156,7 @@ func TestTui(t *testing.T) {
 					func(node *graph.Node[*language.FileInfo]) string { return node.Data.RelPath },
 					screen,
 					true,
+					false,
 					update,
 					nil,
 				)
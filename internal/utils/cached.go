@@ -1,12 +1,74 @@
 package utils
 
+import "sync"
+
+// Cache is a concurrency-safe map meant for memoizing computations.
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*cacheEntry[V]
+}
+
+type cacheEntry[V any] struct {
+	mu    sync.Mutex
+	done  bool
+	value V
+}
+
+func NewCache[K comparable, V any]() *Cache[K, V] {
+	return &Cache[K, V]{entries: make(map[K]*cacheEntry[V])}
+}
+
+func (c *Cache[K, V]) entry(key K) *cacheEntry[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &cacheEntry[V]{}
+		c.entries[key] = e
+	}
+	return e
+}
+
+// Get returns the value stored for key, if any.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	e := c.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.value, e.done
+}
+
+// Set stores value for key.
+func (c *Cache[K, V]) Set(key K, value V) {
+	e := c.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.value, e.done = value, true
+}
+
+// GetOrCompute returns the value stored for key, computing it if it's not there yet.
+// Computations for the same key are serialized, so a value is computed only once even if it's
+// requested from multiple goroutines, while different keys are computed in parallel. Failed
+// computations are not stored, so they are retried the next time.
+//
+// NOTE: compute must not request the same key, as that would block forever.
+func (c *Cache[K, V]) GetOrCompute(key K, compute func() (V, error)) (V, error) {
+	e := c.entry(key)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.done {
+		value, err := compute()
+		if err != nil {
+			return value, err
+		}
+		e.value, e.done = value, true
+	}
+	return e.value, nil
+}
+
 func Cached1In1Out[I comparable, O any](f func(I) O) func(I) O {
-	cache := make(map[I]O)
+	cache := NewCache[I, O]()
 	return func(x I) O {
-		if _, ok := cache[x]; !ok {
-			cache[x] = f(x)
-		}
-		value, _ := cache[x]
+		value, _ := cache.GetOrCompute(x, func() (O, error) { return f(x), nil })
 		return value
 	}
 }
@@ -17,33 +79,16 @@ type in2[I1 comparable, I2 comparable] struct {
 }
 
 func Cached2In1OutErr[I1 comparable, I2 comparable, O1 any](f func(I1, I2) (O1, error)) func(I1, I2) (O1, error) {
-	cache := make(map[in2[I1, I2]]O1)
+	cache := NewCache[in2[I1, I2], O1]()
 	return func(i1 I1, i2 I2) (O1, error) {
-		key := in2[I1, I2]{i1, i2}
-		if _, ok := cache[key]; !ok {
-			o1, err := f(i1, i2)
-			if err != nil {
-				return o1, err
-			}
-			cache[key] = o1
-		}
-		value, _ := cache[key]
-		return value, nil
+		return cache.GetOrCompute(in2[I1, I2]{i1, i2}, func() (O1, error) { return f(i1, i2) })
 	}
 }
 
 func Cached1In1OutErr[I comparable, O1 any](f func(I) (O1, error)) func(I) (O1, error) {
-	cache := make(map[I]O1)
+	cache := NewCache[I, O1]()
 	return func(x I) (O1, error) {
-		if _, ok := cache[x]; !ok {
-			o1, err := f(x)
-			if err != nil {
-				return o1, err
-			}
-			cache[x] = o1
-		}
-		value, _ := cache[x]
-		return value, nil
+		return cache.GetOrCompute(x, func() (O1, error) { return f(x) })
 	}
 }
 
@@ -53,28 +98,23 @@ type out2[O1 any, O2 any] struct {
 }
 
 func Cached1In2OutErr[I comparable, O1 any, O2 any](f func(I) (O1, O2, error)) func(I) (O1, O2, error) {
-	cache := make(map[I]out2[O1, O2])
+	cache := NewCache[I, out2[O1, O2]]()
 	return func(x I) (O1, O2, error) {
-		if _, ok := cache[x]; !ok {
+		value, err := cache.GetOrCompute(x, func() (out2[O1, O2], error) {
 			o1, o2, err := f(x)
-			if err != nil {
-				return o1, o2, err
-			}
-			cache[x] = out2[O1, O2]{o1, o2}
-		}
-		value, _ := cache[x]
-		return value.o1, value.o2, nil
+			return out2[O1, O2]{o1, o2}, err
+		})
+		return value.o1, value.o2, err
 	}
 }
 
 func Cached1In2Out[I comparable, O1 any, O2 any](f func(I) (O1, O2)) func(I) (O1, O2) {
-	cache := make(map[I]out2[O1, O2])
+	cache := NewCache[I, out2[O1, O2]]()
 	return func(x I) (O1, O2) {
-		if _, ok := cache[x]; !ok {
+		value, _ := cache.GetOrCompute(x, func() (out2[O1, O2], error) {
 			o1, o2 := f(x)
-			cache[x] = out2[O1, O2]{o1, o2}
-		}
-		value, _ := cache[x]
+			return out2[O1, O2]{o1, o2}, nil
+		})
 		return value.o1, value.o2
 	}
 }
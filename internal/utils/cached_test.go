@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_GetOrComputeOnce(t *testing.T) {
+	a := require.New(t)
+	cache := NewCache[string, int]()
+
+	var computed atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.GetOrCompute("key", func() (int, error) {
+				computed.Add(1)
+				return 42, nil
+			})
+			a.NoError(err)
+			a.Equal(42, value)
+		}()
+	}
+	wg.Wait()
+	a.Equal(int32(1), computed.Load())
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	a := require.New(t)
+	cache := NewCache[string, int]()
+
+	_, err := cache.GetOrCompute("key", func() (int, error) { return 0, errors.New("failed") })
+	a.ErrorContains(err, "failed")
+	_, ok := cache.Get("key")
+	a.False(ok)
+
+	value, err := cache.GetOrCompute("key", func() (int, error) { return 1, nil })
+	a.NoError(err)
+	a.Equal(1, value)
+}
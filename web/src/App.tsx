@@ -94,6 +94,17 @@ function GraphExplorer({ graphData }: { graphData: Graph }) {
     return `rgba(255, 255, 255, ${alpha})`;
   }
 
+  function linkLabel ({ line, symbols, kinds, weight }: XLink) {
+    if (line == null && symbols == null && kinds == null && weight == null) return ''
+    return `
+    <div class="nodeLabel">
+        ${line != null ? `<span>line: ${line}</span>` : ''}
+        ${symbols != null ? `<span>imports: ${symbols.join(', ')}</span>` : ''}
+        ${kinds != null ? `<span>${kinds.join(', ')}</span>` : ''}
+        ${weight != null ? `<span>${weight} imports</span>` : ''}
+    </div>`
+  }
+
   function nodeThreeObject (node: XNode) {
     const id = node.id.toString()
     let nodeEl = document.getElementById(id)
@@ -205,6 +216,7 @@ function GraphExplorer({ graphData }: { graphData: Graph }) {
         onNodeClick={nodeClick}
         linkDirectionalArrowLength={4}
         linkDirectionalArrowRelPos={1}
+        linkLabel={link => selectedNode ? '' : linkLabel(link)}
         linkColor={colorLink}
         linkDirectionalArrowColor={colorLink}
         linkSource={'from' satisfies keyof XLink}
@@ -17,9 +17,21 @@ export interface Link {
   from: number /* int64 */;
   to: number /* int64 */;
   isCyclic: boolean;
+  weight?: number /* int */;
+  line?: number /* int */;
+  symbols?: string[];
+  kinds?: string[];
+}
+export interface CoChange {
+  from: number /* int64 */;
+  to: number /* int64 */;
+  coChanges: number /* int */;
+  coupling: number /* float64 */;
+  dependent: boolean;
 }
 export interface Graph {
   nodes: Node[];
   links: Link[];
+  coChanges?: CoChange[];
   enableGui: boolean;
 }